@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Krzysztofz01/apikit/cmd/log"
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	configValidatePath   string
+	configValidateOutput string
+	configMigratePath    string
+	configMigrateDryRun  bool
+)
+
+func init() {
+	configValidateCmd.Flags().StringVarP(&configValidatePath, "config", "c", "", "path to the json, yaml or toml config file (defaults to $APIKIT_CONFIG or ./config.json)")
+	configValidateCmd.Flags().StringVarP(&configValidateOutput, "output", "o", "human", "output format of the validation report (human or json)")
+
+	configMigrateCmd.Flags().StringVarP(&configMigratePath, "config", "c", "", "path to the json, yaml or toml config file (defaults to $APIKIT_CONFIG or ./config.json)")
+	configMigrateCmd.Flags().BoolVar(&configMigrateDryRun, "dry-run", false, "only report the changes without rewriting the config files")
+
+	configCmd.AddCommand(configSchemaCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configMigrateCmd)
+
+	rootCmd.AddCommand(configCmd)
+}
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "",
+	Long:  "",
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of the config file",
+	Long:  "",
+	Run: func(_ *cobra.Command, _ []string) {
+		schema, err := config.GenerateJsonSchema()
+		if err != nil {
+			log.FatalErr(err)
+		}
+
+		fmt.Fprintf(os.Stdout, "%s\n", schema)
+	},
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the config file and report all problems with their config paths",
+	Long:  "",
+	Run: func(_ *cobra.Command, _ []string) {
+		if configValidateOutput != "human" && configValidateOutput != "json" {
+			log.FatalErr(fmt.Errorf("cmd: invalid %s validation report output format", configValidateOutput))
+		}
+
+		configPath, err := config.ResolveConfigurationFilePath(configValidatePath)
+		if err != nil {
+			log.FatalErr(err)
+		}
+
+		// NOTE: Failures that are not validation problems (e.g. syntax errors) are reported as a single problem of the file
+		var validationErrs, validationWarnings config.ValidationErrors
+		if configuration, err := config.LoadServerConfigurationFromFile(configPath); err != nil {
+			if !errors.As(err, &validationErrs) {
+				validationErrs = config.ValidationErrors{{File: configPath, Message: err.Error()}}
+			}
+		} else {
+			validationWarnings = configuration.Warnings
+		}
+
+		if configValidateOutput == "json" {
+			report := struct {
+				Valid    bool                    `json:"valid"`
+				Errors   config.ValidationErrors `json:"errors"`
+				Warnings config.ValidationErrors `json:"warnings"`
+			}{
+				Valid:    len(validationErrs) == 0,
+				Errors:   validationErrs,
+				Warnings: validationWarnings,
+			}
+
+			if report.Errors == nil {
+				report.Errors = config.ValidationErrors{}
+			}
+
+			if report.Warnings == nil {
+				report.Warnings = config.ValidationErrors{}
+			}
+
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				log.FatalErr(err)
+			}
+		} else {
+			for _, validationErr := range validationErrs {
+				fmt.Fprintf(os.Stdout, "%s\n", validationErr.Error())
+			}
+
+			for _, validationWarning := range validationWarnings {
+				fmt.Fprintf(os.Stdout, "warning: %s\n", validationWarning.Error())
+			}
+
+			if len(validationErrs) == 0 {
+				fmt.Fprintf(os.Stdout, "%s: configuration is valid with %d warning(s)\n", configPath, len(validationWarnings))
+			} else {
+				fmt.Fprintf(os.Stdout, "%s: %d problem(s) found\n", configPath, len(validationErrs))
+			}
+		}
+
+		if len(validationErrs) != 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Rewrite the config file and the included config files into the current version layout",
+	Long:  "Rewrite the config file and the included config files into the current version layout. The original files are kept with the .bak extension, as the comments and the order of the keys are not preserved.",
+	Run: func(_ *cobra.Command, _ []string) {
+		configPath, err := config.ResolveConfigurationFilePath(configMigratePath)
+		if err != nil {
+			log.FatalErr(err)
+		}
+
+		migrations, err := config.MigrateConfigurationFiles(configPath, !configMigrateDryRun)
+		if err != nil {
+			log.FatalErr(err)
+		}
+
+		for _, migration := range migrations {
+			if migration.FromVersion == migration.ToVersion {
+				fmt.Fprintf(os.Stdout, "%s: already uses the version %d layout\n", migration.Path, migration.ToVersion)
+				continue
+			}
+
+			fmt.Fprintf(os.Stdout, "%s: migrated from version %d to version %d\n", migration.Path, migration.FromVersion, migration.ToVersion)
+			for _, change := range migration.Changes {
+				fmt.Fprintf(os.Stdout, "  %s\n", change)
+			}
+		}
+	},
+}
@@ -3,6 +3,8 @@ package log
 import (
 	"fmt"
 	"os"
+
+	"github.com/Krzysztofz01/apikit/internal/log"
 )
 
 func FatalErr(err interface{}) {
@@ -19,3 +21,17 @@ func FatalErr(err interface{}) {
 	fmt.Fprintf(os.Stderr, "%s\n", message)
 	os.Exit(1)
 }
+
+func FatalErrRedacted(err interface{}, secrets []string) {
+	var message string
+	switch e := err.(type) {
+	case string:
+		message = e
+	case error:
+		message = e.Error()
+	default:
+		message = "cmd: unknown runtime failure"
+	}
+
+	FatalErr(log.Redact(message, secrets))
+}
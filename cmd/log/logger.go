@@ -57,10 +57,10 @@ func CreateBaseLogger(verbose bool) (*zap.Logger, func() error, error) {
 	return logger, dispose, nil
 }
 
-func CreateInternalLogger(l *zap.Logger) log.Logger {
-	return &internalLogger{
+func CreateInternalLogger(l *zap.Logger, secrets []string) log.RedactingLogger {
+	return log.CreateRedactingLogger(secrets, &internalLogger{
 		l: l,
-	}
+	})
 }
 
 type internalLogger struct {
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/Krzysztofz01/apikit/cmd/log"
@@ -13,7 +15,15 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	runConfigPath  string
+	runConfigWatch bool
+)
+
 func init() {
+	runCmd.Flags().StringVarP(&runConfigPath, "config", "c", "", "path to the json, yaml or toml config file (defaults to $APIKIT_CONFIG or ./config.json)")
+	runCmd.Flags().BoolVar(&runConfigWatch, "watch", true, "reload the configuration when the config file changes")
+
 	rootCmd.AddCommand(runCmd)
 }
 
@@ -22,17 +32,27 @@ var runCmd = &cobra.Command{
 	Short: "",
 	Long:  "",
 	Run: func(cmd *cobra.Command, args []string) {
+		// NOTE: Secrets resolved from the config file references are redacted from all runtime failures
+		var secrets []string
+
 		defer func() {
 			if r := recover(); r != nil {
-				log.FatalErr(r)
+				log.FatalErrRedacted(r, secrets)
 			}
 		}()
 
-		configuration, err := config.LoadServerConfigurationFromFile()
+		configPath, err := config.ResolveConfigurationFilePath(runConfigPath)
 		if err != nil {
 			log.FatalErr(err)
 		}
 
+		configuration, err := config.LoadServerConfigurationFromFile(configPath)
+		if err != nil {
+			log.FatalErr(err)
+		}
+
+		secrets = configuration.Secrets
+
 		logger, loggerDispose, err := log.CreateBaseLogger(configuration.VerboseMode)
 		if err != nil {
 			log.FatalErr(err)
@@ -40,15 +60,24 @@ var runCmd = &cobra.Command{
 
 		defer func() {
 			if err := loggerDispose(); err != nil {
-				log.FatalErr(err)
+				log.FatalErrRedacted(err, secrets)
 			}
 		}()
 
-		internalLogger := log.CreateInternalLogger(logger)
+		internalLogger := log.CreateInternalLogger(logger, secrets)
 
-		server, err := server.CreateApiKitServer(http.DefaultClient, configuration, internalLogger)
+		for _, warning := range configuration.Warnings {
+			internalLogger.Warnf("Runtime", "Configuration warning: %s", warning.Error())
+		}
+
+		store, err := config.CreateConfigurationStore(configPath)
 		if err != nil {
-			log.FatalErr(err)
+			log.FatalErrRedacted(err, secrets)
+		}
+
+		server, err := server.CreateApiKitServer(http.DefaultClient, configuration, store, internalLogger)
+		if err != nil {
+			log.FatalErrRedacted(err, secrets)
 		}
 
 		go func() {
@@ -57,9 +86,78 @@ var runCmd = &cobra.Command{
 			}
 		}()
 
+		var (
+			watcher   config.ConfigurationWatcher
+			watcherMu sync.Mutex
+		)
+
+		// NOTE: The config file is loaded under the server reload lock shared with the admin api, so the reloads are
+		// serialized with the admin api changes and the config file writes of the admin api are not reloaded again
+		reload := func(trigger string) {
+			internalLogger.Infof("Runtime", "Configuration reload triggered by %s", trigger)
+
+			reloadedConfiguration, reloaded, err := server.ReloadWith(func() (*config.ApiKitServerConfiguration, error) {
+				reloadedConfiguration, err := config.LoadServerConfigurationFromFile(configPath)
+				if err != nil {
+					return nil, err
+				}
+
+				internalLogger.AddSecrets(reloadedConfiguration.Secrets)
+				return reloadedConfiguration, nil
+			})
+
+			if err != nil {
+				internalLogger.Errorf("Runtime", "Configuration reload failed, keeping the current configuration: %s", err.Error())
+				return
+			}
+
+			if reloaded {
+				for _, warning := range reloadedConfiguration.Warnings {
+					internalLogger.Warnf("Runtime", "Configuration warning: %s", warning.Error())
+				}
+			} else {
+				internalLogger.Infof("Runtime", "Configuration reload skipped, the configuration did not change")
+			}
+
+			// NOTE: The included files could have changed, so the watched files are replaced with the reloaded ones
+			watcherMu.Lock()
+			defer watcherMu.Unlock()
+
+			if watcher != nil {
+				if err := watcher.Watch(reloadedConfiguration.Files, reloadedConfiguration.IncludeDirs); err != nil {
+					internalLogger.Errorf("Runtime", "Configuration watch failure: %s", err.Error())
+				}
+			}
+		}
+
+		if runConfigWatch {
+			watcherMu.Lock()
+			watcher, err = config.CreateConfigurationWatcher(configuration.Files, configuration.IncludeDirs, func() { reload("config file change") }, func(err error) {
+				internalLogger.Errorf("Runtime", "Configuration watch failure: %s", err.Error())
+			})
+			watcherMu.Unlock()
+
+			if err != nil {
+				log.FatalErrRedacted(err, secrets)
+			}
+
+			defer watcher.Close()
+		}
+
 		quit := make(chan os.Signal, 1)
 		signal.Notify(quit, os.Interrupt)
-		<-quit
+
+		hangup := make(chan os.Signal, 1)
+		signal.Notify(hangup, syscall.SIGHUP)
+
+		for running := true; running; {
+			select {
+			case <-hangup:
+				reload("SIGHUP")
+			case <-quit:
+				running = false
+			}
+		}
 
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
@@ -2,22 +2,43 @@ package client
 
 import (
 	"fmt"
+	"maps"
+	"math"
 	"net/http"
+	"reflect"
+	"slices"
+	"time"
 
 	"github.com/Krzysztofz01/apikit/internal/config"
 	"github.com/Krzysztofz01/apikit/internal/log"
 	"github.com/Krzysztofz01/apikit/internal/source"
+	"github.com/Krzysztofz01/apikit/internal/utils"
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
 )
 
 type ApiKitClient interface {
+	// Get the endpoint values
 	Get(endpointName string) (map[string]interface{}, error)
+
+	// Get the endpoint values wrapped in a envelope with units, provenance and content age of each value
+	GetWithMetadata(endpointName string) (map[string]interface{}, error)
 }
 
 type apiKitClient struct {
 	httpClient *http.Client
 	sources    map[string]source.Source
 	lookup     EndpointLookup
+	endpoints  map[string]*config.EndpointConfiguration
+	computed   map[string][]computedValue
 	logger     log.Loggerp
+	cfg        *config.ApiKitConfiguration
+}
+
+type computedValue struct {
+	name    string
+	program *vm.Program
+	inputs  []string
 }
 
 func CreateApiKitClient(h *http.Client, c *config.ApiKitConfiguration, l log.Logger) (ApiKitClient, error) {
@@ -25,6 +46,22 @@ func CreateApiKitClient(h *http.Client, c *config.ApiKitConfiguration, l log.Log
 }
 
 func CreateNamedApiKitClient(name string, h *http.Client, c *config.ApiKitConfiguration, l log.Logger) (ApiKitClient, error) {
+	return createApiKitClient(name, h, c, l, nil)
+}
+
+// Create the client of the reloaded configuration. The sources of the previous client are reused if their configuration
+// and the configuration of the referenced mappings did not change, so the sources keep the cached html content and the
+// monotonic constraints state
+func CreateReloadedApiKitClient(previous ApiKitClient, h *http.Client, c *config.ApiKitConfiguration, l log.Logger) (ApiKitClient, error) {
+	previousClient, ok := previous.(*apiKitClient)
+	if !ok {
+		return nil, fmt.Errorf("client: invalid previous client provided")
+	}
+
+	return createApiKitClient("", h, c, l, previousClient)
+}
+
+func createApiKitClient(name string, h *http.Client, c *config.ApiKitConfiguration, l log.Logger, previous *apiKitClient) (ApiKitClient, error) {
 	if h == nil {
 		return nil, fmt.Errorf("client: invalid nil reference http client provided")
 	}
@@ -44,9 +81,21 @@ func CreateNamedApiKitClient(name string, h *http.Client, c *config.ApiKitConfig
 
 	logger := log.CreatePrefixedLogger(prefix, l)
 
+	mappings, err := source.CreateMappings(c.Mappings)
+	if err != nil {
+		return nil, fmt.Errorf("client: failed to create mappings: %w", err)
+	}
+
 	sources := make(map[string]source.Source, len(c.Sources))
 	for _, sourceConfig := range c.Sources {
-		if source, err := source.CreateSource(h, sourceConfig, l); err != nil {
+		if previous != nil {
+			if previousSource, ok := previous.reusableSource(sourceConfig, c); ok {
+				sources[sourceConfig.Name] = previousSource
+				continue
+			}
+		}
+
+		if source, err := source.CreateSource(h, sourceConfig, mappings, l); err != nil {
 			return nil, fmt.Errorf("client: failed to create source instance: %w", err)
 		} else {
 			sources[sourceConfig.Name] = source
@@ -58,40 +107,242 @@ func CreateNamedApiKitClient(name string, h *http.Client, c *config.ApiKitConfig
 		return nil, fmt.Errorf("client: failed to create the client endpoint lookup: %w", err)
 	}
 
+	endpoints := make(map[string]*config.EndpointConfiguration, len(c.Endpoints))
+	computed := make(map[string][]computedValue, len(c.Endpoints))
+	for _, endpointConfig := range c.Endpoints {
+		endpoints[endpointConfig.Name] = endpointConfig
+
+		expressionEnv, err := c.CreateExpressionEnv(endpointConfig)
+		if err != nil {
+			return nil, fmt.Errorf("client: failed to create the endpoint expression environment: %w", err)
+		}
+
+		for _, endpointValueConfig := range endpointConfig.Values {
+			if !endpointValueConfig.IsComputed() {
+				continue
+			}
+
+			if program, err := config.CompileExpression(endpointValueConfig.Expression, expressionEnv); err != nil {
+				return nil, fmt.Errorf("client: failed to compile the endpoint value expression: %w", err)
+			} else {
+				computed[endpointConfig.Name] = append(computed[endpointConfig.Name], computedValue{
+					name:    endpointValueConfig.Name,
+					program: program,
+					inputs:  config.ExpressionInputs(program, expressionEnv),
+				})
+			}
+		}
+	}
+
 	return &apiKitClient{
 		httpClient: h,
 		sources:    sources,
 		lookup:     lookup,
+		endpoints:  endpoints,
+		computed:   computed,
 		logger:     logger,
+		cfg:        c,
 	}, nil
 }
 
+// Get the source of the client that can be reused for the given source configuration of the reloaded configuration
+func (c *apiKitClient) reusableSource(sourceConfig *config.SourceConfiguration, reloaded *config.ApiKitConfiguration) (source.Source, bool) {
+	previousSource, ok := c.sources[sourceConfig.Name]
+	if !ok {
+		return nil, false
+	}
+
+	index := slices.IndexFunc(c.cfg.Sources, func(s *config.SourceConfiguration) bool { return s.Name == sourceConfig.Name })
+	if index == -1 || !reflect.DeepEqual(c.cfg.Sources[index], sourceConfig) {
+		return nil, false
+	}
+
+	// NOTE: The source holds the mappings referenced by its values, so a changed mapping requires a new source
+	for _, value := range sourceConfig.Values {
+		if len(value.Mapping) == 0 {
+			continue
+		}
+
+		if !reflect.DeepEqual(findMappingConfiguration(c.cfg, value.Mapping), findMappingConfiguration(reloaded, value.Mapping)) {
+			return nil, false
+		}
+	}
+
+	return previousSource, true
+}
+
+func findMappingConfiguration(c *config.ApiKitConfiguration, name string) *config.MappingConfiguration {
+	for _, mapping := range c.Mappings {
+		if mapping.Name == name {
+			return mapping
+		}
+	}
+
+	return nil
+}
+
 func (c *apiKitClient) Get(endpointName string) (map[string]interface{}, error) {
+	return c.get(endpointName, false)
+}
+
+func (c *apiKitClient) GetWithMetadata(endpointName string) (map[string]interface{}, error) {
+	return c.get(endpointName, true)
+}
+
+func (c *apiKitClient) get(endpointName string, metadata bool) (map[string]interface{}, error) {
 	sourceValuesMap, err := c.lookup.GetEndpointSourcesWithSourceValueNames(endpointName)
 	if err != nil {
 		return nil, fmt.Errorf("client: failed to access the endpoint via lookup: %w", err)
 	}
 
+	endpoint, ok := c.endpoints[endpointName]
+	if !ok {
+		return nil, fmt.Errorf("client: specified endpoint not found")
+	}
+
+	t := time.Now().UTC()
+
 	result := make(map[string]interface{}, 0)
+	valuesMetadata := make(map[string]map[string]interface{}, 0)
+	oldestFetchedAt := t
 	for sourceName, sourceValueNames := range sourceValuesMap {
 		source, ok := c.sources[sourceName]
 		if !ok {
 			return nil, fmt.Errorf("client: specified source not found")
 		}
 
-		sourceValues, err := source.GetValues(sourceValueNames)
+		sourceValues, sourceMetadata, err := source.GetValuesWithMetadata(sourceValueNames)
 		if err != nil {
 			return nil, fmt.Errorf("client: failed to access the source values: %w", err)
 		}
 
-		for sourceValueName, sourceValue := range sourceValues {
-			if endpointValueName, err := c.lookup.GetEndpointValueName(sourceName, sourceValueName); err != nil {
-				return nil, fmt.Errorf("client: failed to access the endpoint value name via lookup: %w", err)
-			} else {
+		if sourceMetadata.FetchedAt.Before(oldestFetchedAt) {
+			oldestFetchedAt = sourceMetadata.FetchedAt
+		}
+
+		for _, sourceValueName := range sourceValueNames {
+			sourceValue, ok := sourceValues[sourceValueName]
+			if !ok && endpoint.MissingValues == config.SkipMissingValues {
+				continue
+			}
+
+			endpointValueNames, err := c.lookup.GetEndpointValueNames(endpointName, sourceName, sourceValueName)
+			if err != nil {
+				return nil, fmt.Errorf("client: failed to access the endpoint value names via lookup: %w", err)
+			}
+
+			for _, endpointValueName := range endpointValueNames {
 				result[endpointValueName] = sourceValue
+
+				if metadata {
+					valuesMetadata[endpointValueName] = c.createSourceValueMetadata(sourceName, sourceValueName, sourceMetadata, t)
+				}
+			}
+		}
+	}
+
+	// NOTE: Computed values are evaluated only against the source backed values
+	expressionEnv := maps.Clone(result)
+	for _, computedValue := range c.computed[endpointName] {
+		value, err := c.evaluateComputedValue(computedValue, expressionEnv)
+		if err != nil {
+			return nil, fmt.Errorf("client: failed to evaluate the %s endpoint value expression: %w", computedValue.name, err)
+		}
+
+		if value == nil && endpoint.MissingValues == config.SkipMissingValues {
+			continue
+		}
+
+		result[computedValue.name] = value
+	}
+
+	for _, endpointValueConfig := range endpoint.Values {
+		if endpointValueConfig.Hidden {
+			delete(result, endpointValueConfig.Name)
+			continue
+		}
+
+		if metadata && endpointValueConfig.IsComputed() {
+			valuesMetadata[endpointValueConfig.Name] = map[string]interface{}{
+				"expression": endpointValueConfig.Expression,
 			}
 		}
 	}
 
-	return result, nil
+	if metadata {
+		for name, value := range result {
+			valueMetadata, ok := valuesMetadata[name]
+			if !ok {
+				valueMetadata = make(map[string]interface{}, 1)
+			}
+
+			valueMetadata["value"] = value
+
+			result[name] = valueMetadata
+		}
+	}
+
+	if endpoint.NestedValues {
+		if nestedResult, err := utils.NestPaths(result); err != nil {
+			return nil, fmt.Errorf("client: failed to nest the endpoint values: %w", err)
+		} else {
+			result = nestedResult
+		}
+	}
+
+	if !metadata {
+		return result, nil
+	}
+
+	return map[string]interface{}{
+		"values":    result,
+		"fetchedAt": oldestFetchedAt.Format(time.RFC3339),
+		"staleness": t.Sub(oldestFetchedAt).Seconds(),
+	}, nil
+}
+
+// Evaluate the computed value expression. The computed value is missing (nil) if any of the referenced values is missing
+// or the result is not a finite number, so it is resolved by the endpoint missing values strategy like the source values
+func (c *apiKitClient) evaluateComputedValue(v computedValue, env map[string]interface{}) (interface{}, error) {
+	for _, input := range v.inputs {
+		if value, ok := env[input]; !ok || value == nil {
+			c.logger.Debugf("Computed value %s is missing due to the missing %s value", v.name, input)
+			return nil, nil
+		}
+	}
+
+	value, err := expr.Run(v.program, env)
+	if err != nil {
+		return nil, err
+	}
+
+	if number, ok := value.(float64); ok && (math.IsNaN(number) || math.IsInf(number, 0)) {
+		c.logger.Debugf("Computed value %s is missing due to the not finite %v result", v.name, number)
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+func (c *apiKitClient) createSourceValueMetadata(sourceName, sourceValueName string, sourceMetadata *source.SourceMetadata, t time.Time) map[string]interface{} {
+	valueMetadata := map[string]interface{}{
+		"source":    sourceName,
+		"fetchedAt": sourceMetadata.FetchedAt.Format(time.RFC3339),
+		"cached":    sourceMetadata.Cached,
+		"cacheAge":  t.Sub(sourceMetadata.FetchedAt).Seconds(),
+	}
+
+	if sourceValueConfig, ok := c.cfg.FindSourceValue(sourceName, sourceValueName); ok {
+		if len(sourceValueConfig.Unit) != 0 {
+			valueMetadata["unit"] = sourceValueConfig.Unit
+		}
+
+		if len(sourceValueConfig.CssSelector) != 0 {
+			valueMetadata["cssSelector"] = sourceValueConfig.CssSelector
+		} else {
+			valueMetadata["xpath"] = sourceValueConfig.Xpath
+		}
+	}
+
+	return valueMetadata
 }
@@ -0,0 +1,293 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogger struct{}
+
+func (testLogger) Debugf(prefix, format string, args ...interface{}) {}
+func (testLogger) Infof(prefix, format string, args ...interface{})  {}
+func (testLogger) Warnf(prefix, format string, args ...interface{})  {}
+func (testLogger) Errorf(prefix, format string, args ...interface{}) {}
+
+func createTestClient(t *testing.T, content string, values []*config.SourceValueConfiguration, endpoint *config.EndpointConfiguration) ApiKitClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(content))
+	}))
+
+	t.Cleanup(server.Close)
+
+	c := &config.ApiKitConfiguration{
+		Sources: []*config.SourceConfiguration{
+			{Name: "router", Url: server.URL, TimeoutSeconds: 5, Values: values},
+		},
+		Endpoints: []*config.EndpointConfiguration{endpoint},
+		Mappings:  []*config.MappingConfiguration{},
+	}
+
+	client, err := CreateApiKitClient(server.Client(), c, testLogger{})
+	assert.Nil(t, err)
+
+	return client
+}
+
+func TestGetWithMetadataShouldReturnValuesMetadata(t *testing.T) {
+	client := createTestClient(t, `<html><body><p id="uptime">120</p></body></html>`, []*config.SourceValueConfiguration{
+		{Name: "uptime", Xpath: `//p[@id="uptime"]`, Type: config.Int, Unit: "s"},
+	}, &config.EndpointConfiguration{Name: "status", Values: []*config.EndpointValueConfiguration{
+		{Name: "uptime", SourceName: "router", SourceValueName: "uptime"},
+		{Name: "uptimeMinutes", Expression: "uptime / 60"},
+	}})
+
+	result, err := client.GetWithMetadata("status")
+	assert.Nil(t, err)
+
+	values := result["values"].(map[string]interface{})
+	uptime := values["uptime"].(map[string]interface{})
+	assert.Equal(t, 120, uptime["value"])
+	assert.Equal(t, "router", uptime["source"])
+	assert.Equal(t, "s", uptime["unit"])
+	assert.Equal(t, `//p[@id="uptime"]`, uptime["xpath"])
+	assert.Contains(t, uptime, "fetchedAt")
+	assert.Contains(t, uptime, "cached")
+	assert.Contains(t, uptime, "cacheAge")
+	assert.NotContains(t, uptime, "url")
+
+	uptimeMinutes := values["uptimeMinutes"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"expression": "uptime / 60", "value": 2.0}, uptimeMinutes)
+
+	assert.Contains(t, result, "fetchedAt")
+	assert.Contains(t, result, "staleness")
+}
+
+func TestGetShouldResolveComputedValuesWithMissingInputs(t *testing.T) {
+	cases := []struct {
+		content       string
+		missingValues config.MissingValuesStrategy
+		expected      map[string]interface{}
+	}{
+		{
+			content:       `<p id="rx">30</p><p id="tx">10</p>`,
+			missingValues: config.NullMissingValues,
+			expected:      map[string]interface{}{"rx": uint64(30), "tx": uint64(10), "ratio": 0.75},
+		},
+		{
+			content:       `<p id="rx">30</p>`,
+			missingValues: config.NullMissingValues,
+			expected:      map[string]interface{}{"rx": uint64(30), "tx": nil, "ratio": nil},
+		},
+		{
+			content:       `<p id="rx">30</p>`,
+			missingValues: config.SkipMissingValues,
+			expected:      map[string]interface{}{"rx": uint64(30)},
+		},
+		{
+			content:       `<p id="rx">0</p><p id="tx">0</p>`,
+			missingValues: config.NullMissingValues,
+			expected:      map[string]interface{}{"rx": uint64(0), "tx": uint64(0), "ratio": nil},
+		},
+	}
+
+	for _, c := range cases {
+		client := createTestClient(t, c.content, []*config.SourceValueConfiguration{
+			{Name: "rx", Xpath: `//p[@id="rx"]`, Type: config.Uint64, Required: true},
+			{Name: "tx", Xpath: `//p[@id="tx"]`, Type: config.Uint64},
+		}, &config.EndpointConfiguration{Name: "traffic", MissingValues: c.missingValues, Values: []*config.EndpointValueConfiguration{
+			{Name: "rx", SourceName: "router", SourceValueName: "rx"},
+			{Name: "tx", SourceName: "router", SourceValueName: "tx"},
+			{Name: "ratio", Expression: "rx / (rx + tx)"},
+		}})
+
+		actual, err := client.Get("traffic")
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestGetShouldNestValuesOnlyWhenEnabled(t *testing.T) {
+	cases := []struct {
+		nestedValues bool
+		expected     map[string]interface{}
+	}{
+		{
+			nestedValues: false,
+			expected:     map[string]interface{}{"wan.ip": "10.0.0.1", "dns[0]": "1.1.1.1"},
+		},
+		{
+			nestedValues: true,
+			expected:     map[string]interface{}{"wan": map[string]interface{}{"ip": "10.0.0.1"}, "dns": []interface{}{"1.1.1.1"}},
+		},
+	}
+
+	for _, c := range cases {
+		client := createTestClient(t, `<p id="ip">10.0.0.1</p><p id="dns">1.1.1.1</p>`, []*config.SourceValueConfiguration{
+			{Name: "ip", Xpath: `//p[@id="ip"]`, Type: config.String},
+			{Name: "dns", Xpath: `//p[@id="dns"]`, Type: config.String},
+		}, &config.EndpointConfiguration{Name: "network", NestedValues: c.nestedValues, Values: []*config.EndpointValueConfiguration{
+			{Name: "wan.ip", SourceName: "router", SourceValueName: "ip"},
+			{Name: "dns[0]", SourceName: "router", SourceValueName: "dns"},
+		}})
+
+		actual, err := client.Get("network")
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestGetShouldResolveOptionalValuesAndDefaults(t *testing.T) {
+	cases := []struct {
+		content       string
+		missingValues config.MissingValuesStrategy
+		expected      map[string]interface{}
+		fails         bool
+	}{
+		{
+			content:       `<p id="rx">30</p><p id="tx">10</p><p id="rssi">-60</p><p id="model">AX3000</p>`,
+			missingValues: config.NullMissingValues,
+			expected:      map[string]interface{}{"rx": 30, "tx": 10, "rssi": -60, "model": "AX3000"},
+		},
+		{
+			content:       `<p id="rx">30</p>`,
+			missingValues: config.NullMissingValues,
+			expected:      map[string]interface{}{"rx": 30, "tx": nil, "rssi": 0, "model": nil},
+		},
+		{
+			content:       `<p id="rx">30</p>`,
+			missingValues: config.SkipMissingValues,
+			expected:      map[string]interface{}{"rx": 30, "rssi": 0, "model": nil},
+		},
+		{
+			content:       `<p id="tx">10</p>`,
+			missingValues: config.NullMissingValues,
+			fails:         true,
+		},
+		{
+			content:       `<p id="tx">10</p>`,
+			missingValues: config.SkipMissingValues,
+			fails:         true,
+		},
+	}
+
+	for _, c := range cases {
+		client := createTestClient(t, c.content, []*config.SourceValueConfiguration{
+			{Name: "rx", Xpath: `//p[@id="rx"]`, Type: config.Int, Required: true},
+			{Name: "tx", Xpath: `//p[@id="tx"]`, Type: config.Int},
+			{Name: "rssi", Xpath: `//p[@id="rssi"]`, Type: config.Int, Default: 0, HasDefault: true},
+			{Name: "model", Xpath: `//p[@id="model"]`, Type: config.String, Default: nil, HasDefault: true},
+		}, &config.EndpointConfiguration{Name: "status", MissingValues: c.missingValues, Values: []*config.EndpointValueConfiguration{
+			{Name: "rx", SourceName: "router", SourceValueName: "rx"},
+			{Name: "tx", SourceName: "router", SourceValueName: "tx"},
+			{Name: "rssi", SourceName: "router", SourceValueName: "rssi"},
+			{Name: "model", SourceName: "router", SourceValueName: "model"},
+		}})
+
+		actual, err := client.Get("status")
+
+		if c.fails {
+			assert.NotNil(t, err)
+		} else {
+			assert.Nil(t, err)
+			assert.Equal(t, c.expected, actual)
+		}
+	}
+}
+
+func TestCreateReloadedApiKitClientShouldReuseUnchangedSources(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Write([]byte(`<p id="uptime">120</p>`))
+	}))
+
+	defer server.Close()
+
+	createConfiguration := func(timeoutSeconds int) *config.ApiKitConfiguration {
+		return &config.ApiKitConfiguration{
+			Sources: []*config.SourceConfiguration{
+				{Name: "router", Url: server.URL, TimeoutSeconds: timeoutSeconds, CachingEnable: true, CachingLifeTimeSeconds: 60, Values: []*config.SourceValueConfiguration{
+					{Name: "uptime", Xpath: `//p[@id="uptime"]`, Type: config.Int, Required: true},
+				}},
+			},
+			Endpoints: []*config.EndpointConfiguration{{Name: "status", Values: []*config.EndpointValueConfiguration{
+				{Name: "uptime", SourceName: "router", SourceValueName: "uptime"},
+			}}},
+			Mappings: []*config.MappingConfiguration{},
+		}
+	}
+
+	cases := []struct {
+		timeoutSeconds   int
+		expectedRequests int
+	}{
+		{timeoutSeconds: 5, expectedRequests: 1},
+		{timeoutSeconds: 5, expectedRequests: 1},
+		{timeoutSeconds: 10, expectedRequests: 2},
+	}
+
+	client, err := CreateApiKitClient(server.Client(), createConfiguration(5), testLogger{})
+	assert.Nil(t, err)
+
+	for _, c := range cases {
+		client, err = CreateReloadedApiKitClient(client, server.Client(), createConfiguration(c.timeoutSeconds), testLogger{})
+		assert.Nil(t, err)
+
+		_, err = client.Get("status")
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expectedRequests, requests)
+	}
+}
+
+func TestGetShouldResolveSourceValuesSharedByEndpointValues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`<p id="rx">3000</p>`))
+	}))
+
+	defer server.Close()
+
+	c := &config.ApiKitConfiguration{
+		Sources: []*config.SourceConfiguration{
+			{Name: "router", Url: server.URL, TimeoutSeconds: 5, Values: []*config.SourceValueConfiguration{
+				{Name: "rx", Xpath: `//p[@id="rx"]`, Type: config.Int, Required: true},
+			}},
+		},
+		Endpoints: []*config.EndpointConfiguration{
+			{Name: "traffic", Values: []*config.EndpointValueConfiguration{
+				{Name: "rxBytes", SourceName: "router", SourceValueName: "rx", Hidden: true},
+				{Name: "rx", SourceName: "router", SourceValueName: "rx"},
+				{Name: "rxKilobytes", Expression: "rxBytes / 1000"},
+			}},
+			{Name: "status", Values: []*config.EndpointValueConfiguration{
+				{Name: "received", SourceName: "router", SourceValueName: "rx"},
+				{Name: "receivedKilobytes", Expression: "received / 1000"},
+			}},
+		},
+		Mappings: []*config.MappingConfiguration{},
+	}
+
+	client, err := CreateApiKitClient(server.Client(), c, testLogger{})
+	assert.Nil(t, err)
+
+	cases := []struct {
+		endpointName string
+		expected     map[string]interface{}
+	}{
+		{endpointName: "traffic", expected: map[string]interface{}{"rx": 3000, "rxKilobytes": 3.0}},
+		{endpointName: "status", expected: map[string]interface{}{"received": 3000, "receivedKilobytes": 3.0}},
+	}
+
+	for _, c := range cases {
+		actual, err := client.Get(c.endpointName)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
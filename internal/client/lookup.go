@@ -8,12 +8,12 @@ import (
 
 type EndpointLookup interface {
 	GetEndpointSourcesWithSourceValueNames(endpointName string) (map[string][]string, error)
-	GetEndpointValueName(sourceName, sourceValueName string) (string, error)
+	GetEndpointValueNames(endpointName, sourceName, sourceValueName string) ([]string, error)
 }
 
 type endpointLookup struct {
-	endpointSourcesWithSourceValuesLookup    map[string]map[string][]string
-	sourceValueNameToEndpointValueNameLookup map[string]string
+	endpointSourcesWithSourceValuesLookup     map[string]map[string][]string
+	sourceValueNameToEndpointValueNamesLookup map[string]map[string][]string
 }
 
 func (l endpointLookup) GetEndpointSourcesWithSourceValueNames(endpointName string) (map[string][]string, error) {
@@ -24,13 +24,14 @@ func (l endpointLookup) GetEndpointSourcesWithSourceValueNames(endpointName stri
 	}
 }
 
-func (l endpointLookup) GetEndpointValueName(sourceName string, sourceValueName string) (string, error) {
+// NOTE: A source value can be used by multiple values of the endpoint (e.g. a hidden computed value input and its visible copy)
+func (l endpointLookup) GetEndpointValueNames(endpointName, sourceName, sourceValueName string) ([]string, error) {
 	lookupKey := fmt.Sprintf("%s%s%s", sourceName, lookupKeySeparator, sourceValueName)
 
-	if endpointValueName, ok := l.sourceValueNameToEndpointValueNameLookup[lookupKey]; !ok {
-		return "", fmt.Errorf("client: specified pair of source and value are not matching and endpoint value")
+	if endpointValueNames, ok := l.sourceValueNameToEndpointValueNamesLookup[endpointName][lookupKey]; !ok {
+		return nil, fmt.Errorf("client: specified pair of source and value are not matching and endpoint value")
 	} else {
-		return endpointValueName, nil
+		return endpointValueNames, nil
 	}
 }
 
@@ -40,30 +41,41 @@ const (
 
 func createEndpointLookup(c *config.ApiKitConfiguration) (EndpointLookup, error) {
 	var (
-		endpointSourcesWithSourceValuesLookup    = make(map[string]map[string][]string, len(c.Endpoints))
-		sourceValueNameToEndpointValueNameLookup = make(map[string]string)
+		endpointSourcesWithSourceValuesLookup     = make(map[string]map[string][]string, len(c.Endpoints))
+		sourceValueNameToEndpointValueNamesLookup = make(map[string]map[string][]string, len(c.Endpoints))
 	)
 
 	for _, endpointConfig := range c.Endpoints {
 		sourcesWithSourceValuesLookup := make(map[string][]string)
+		sourceValueNameToEndpointValueNames := make(map[string][]string)
 		for _, endpointValueConfig := range endpointConfig.Values {
-			// NOTE: Part related to "endpointSourcesWithSourceValuesLookup"
+			// NOTE: Computed values are not backed by any source value
+			if endpointValueConfig.IsComputed() {
+				continue
+			}
+
+			// NOTE: Part related to "sourceValueNameToEndpointValueNamesLookup"
+			lookupKey := fmt.Sprintf("%s%s%s", endpointValueConfig.SourceName, lookupKeySeparator, endpointValueConfig.SourceValueName)
+			sourceValueNameToEndpointValueNames[lookupKey] = append(sourceValueNameToEndpointValueNames[lookupKey], endpointValueConfig.Name)
+
+			// NOTE: Part related to "endpointSourcesWithSourceValuesLookup", the source values are requested once
+			if len(sourceValueNameToEndpointValueNames[lookupKey]) != 1 {
+				continue
+			}
+
 			if _, ok := sourcesWithSourceValuesLookup[endpointValueConfig.SourceName]; !ok {
 				sourcesWithSourceValuesLookup[endpointValueConfig.SourceName] = []string{endpointValueConfig.SourceValueName}
 			} else {
 				sourcesWithSourceValuesLookup[endpointValueConfig.SourceName] = append(sourcesWithSourceValuesLookup[endpointValueConfig.SourceName], endpointValueConfig.SourceValueName)
 			}
-
-			// NOTE: Part related to "sourceValueNameToEndpointValueNameLookup"
-			lookupKey := fmt.Sprintf("%s%s%s", endpointValueConfig.SourceName, lookupKeySeparator, endpointValueConfig.SourceValueName)
-			sourceValueNameToEndpointValueNameLookup[lookupKey] = endpointValueConfig.Name
 		}
 
 		endpointSourcesWithSourceValuesLookup[endpointConfig.Name] = sourcesWithSourceValuesLookup
+		sourceValueNameToEndpointValueNamesLookup[endpointConfig.Name] = sourceValueNameToEndpointValueNames
 	}
 
 	return endpointLookup{
-		endpointSourcesWithSourceValuesLookup:    endpointSourcesWithSourceValuesLookup,
-		sourceValueNameToEndpointValueNameLookup: sourceValueNameToEndpointValueNameLookup,
+		endpointSourcesWithSourceValuesLookup:     endpointSourcesWithSourceValuesLookup,
+		sourceValueNameToEndpointValueNamesLookup: sourceValueNameToEndpointValueNamesLookup,
 	}, nil
 }
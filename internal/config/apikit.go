@@ -1,133 +1,286 @@
 package config
 
 import (
+	"math"
 	"net/url"
 	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"github.com/Krzysztofz01/apikit/internal/utils"
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/xpath"
 )
 
 func Validate(c *ApiKitConfiguration) (bool, string) {
-	return c.isValid()
+	if errs := c.validate("general"); len(errs) != 0 {
+		return false, errs.Error()
+	}
+
+	return true, ""
 }
 
 type ApiKitConfiguration struct {
 	Sources   []*SourceConfiguration
 	Endpoints []*EndpointConfiguration
+	Mappings  []*MappingConfiguration
 }
 
-func (c *ApiKitConfiguration) isValid() (bool, string) {
+func (c *ApiKitConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
 	if c.Sources == nil {
-		return false, "uninitialized sources collection"
+		errs.add(keyPath(path, "sources"), "uninitialized sources collection")
 	}
 
 	if c.Endpoints == nil {
-		return false, "uninitialized endpoints collection"
+		errs.add(keyPath(path, "endpoints"), "uninitialized endpoints collection")
+	}
+
+	// NOTE: Mapping names unique validation
+	mappingNames := utils.NewEmptySet[string]()
+	for index, mapping := range c.Mappings {
+		mappingPath := indexPath(path, "mappings", index)
+
+		// NOTE: Inner mapping config values validation
+		errs = append(errs, mapping.validate(mappingPath)...)
+
+		if !mappingNames.Add(mapping.Name) {
+			errs.add(keyPath(mappingPath, "name"), "duplicate mapping name found")
+		}
 	}
 
 	sourcesValues := make(map[string]utils.Set[string], len(c.Sources))
-	for _, source := range c.Sources {
+	for sourceIndex, source := range c.Sources {
+		sourcePath := indexPath(path, "sources", sourceIndex)
+
 		// NOTE: Inner source config values validation
-		if valid, msg := source.isValid(); !valid {
-			return false, msg
-		}
+		errs = append(errs, source.validate(sourcePath)...)
 
 		// NOTE: Map sourcesValues and value names unique validation
 		sourceValues := utils.NewEmptySet[string]()
-		for _, value := range source.Values {
+		for valueIndex, value := range source.Values {
+			valuePath := indexPath(sourcePath, "values", valueIndex)
+
 			// NOTE: Inner source value config values validation
-			if valid, msg := value.isValid(); !valid {
-				return false, msg
-			}
+			errs = append(errs, value.validate(valuePath)...)
 
 			if !sourceValues.Add(value.Name) {
-				return false, "duplicate source value name found"
+				errs.add(keyPath(valuePath, "name"), "duplicate source value name found")
+			}
+
+			// NOTE: Values emitted by regex groups share the source value names
+			for groupIndex, group := range value.ExtractionRegexGroups {
+				if !sourceValues.Add(group.Name) {
+					errs.add(keyPath(indexPath(valuePath, "extraction-regex-groups", groupIndex), "name"), "duplicate source value name found")
+				}
+			}
+
+			// NOTE: Source value mapping name check
+			if len(value.Mapping) != 0 && !mappingNames.Contains(value.Mapping) {
+				errs.add(keyPath(valuePath, "mapping"), "source value references non existing mapping")
 			}
 		}
 
 		// NOTE: Map sourcesValues and source names unique validation
 		if _, exist := sourcesValues[source.Name]; exist {
-			return false, "duplicate source name found"
+			errs.add(keyPath(sourcePath, "name"), "duplicate source name found")
 		} else {
 			sourcesValues[source.Name] = sourceValues
 		}
 	}
 
 	endpointsValues := make(map[string]utils.Set[string], len(c.Endpoints))
-	for _, endpoint := range c.Endpoints {
+	for endpointIndex, endpoint := range c.Endpoints {
+		endpointPath := indexPath(path, "endpoints", endpointIndex)
+
 		// NOTE: Inner endpoint config values validation
-		if valid, msg := endpoint.isValid(); !valid {
-			return false, msg
-		}
+		errs = append(errs, endpoint.validate(endpointPath)...)
 
 		// NOTE: Map endpointsValues and value names unique validation
 		endpointValues := utils.NewEmptySet[string]()
-		for _, value := range endpoint.Values {
+		for valueIndex, value := range endpoint.Values {
+			valuePath := indexPath(endpointPath, "values", valueIndex)
+
 			// NOTE: Inner endpoint value config values check
-			if valid, msg := value.isValid(); !valid {
-				return false, msg
-			}
+			errs = append(errs, value.validate(valuePath)...)
 
 			if !endpointValues.Add(value.Name) {
-				return false, "duplicate endpoint value name found"
+				errs.add(keyPath(valuePath, "name"), "duplicate endpoint value name found")
+			}
+
+			// NOTE: Computed values are checked after all source backed values are known
+			if value.IsComputed() {
+				continue
 			}
 
 			// NOTE: Endpoint source name check
 			targetSource, targetSourceExist := sourcesValues[value.SourceName]
 			if !targetSourceExist {
-				return false, "endpoint references non existing source"
+				if len(value.SourceName) != 0 {
+					errs.add(keyPath(valuePath, "source-name"), "endpoint references non existing source")
+				}
+
+				continue
 			}
 
 			// NOTE: Endpoint source value name check
-			if !targetSource.Contains(value.SourceValueName) {
-				return false, "endpoint references non existing source value"
+			if len(value.SourceValueName) != 0 && !targetSource.Contains(value.SourceValueName) {
+				errs.add(keyPath(valuePath, "source-value-name"), "endpoint references non existing source value")
+			}
+		}
+
+		// NOTE: Endpoint value names nested paths and paths conflicts check
+		if endpoint.NestedValues {
+			endpointValuesPaths := make(map[string]interface{}, len(endpoint.Values))
+			for valueIndex, value := range endpoint.Values {
+				if len(value.Name) == 0 {
+					continue
+				}
+
+				if _, err := utils.ParsePath(value.Name); err != nil {
+					errs.add(keyPath(indexPath(endpointPath, "values", valueIndex), "name"), "invalid endpoint value name path")
+				} else if !value.Hidden {
+					endpointValuesPaths[value.Name] = nil
+				}
+			}
+
+			if _, err := utils.NestPaths(endpointValuesPaths); err != nil {
+				errs.add(keyPath(endpointPath, "values"), "endpoint value names with conflicting paths")
+			}
+		}
+
+		// NOTE: Endpoint computed values expression type check against the source values types. The non existing
+		// source values are already reported by the source value references check
+		if expressionEnv, err := c.CreateExpressionEnv(endpoint); err == nil {
+			for valueIndex, value := range endpoint.Values {
+				if !value.IsComputed() {
+					continue
+				}
+
+				if _, err := CompileExpression(value.Expression, expressionEnv); err != nil {
+					errs.add(keyPath(indexPath(endpointPath, "values", valueIndex), "expression"), "invalid endpoint value expression that could not be compiled")
+				}
 			}
 		}
 
 		// NOTE: Map endpointsValues and endpoint names unique validation
 		if _, exist := endpointsValues[endpoint.Name]; exist {
-			return false, "duplicate endpoint name found"
+			errs.add(keyPath(endpointPath, "name"), "duplicate endpoint name found")
 		} else {
-			sourcesValues[endpoint.Name] = endpointValues
+			endpointsValues[endpoint.Name] = endpointValues
 		}
 	}
 
-	return true, ""
+	return errs
+}
+
+type MappingConfiguration struct {
+	Name            string
+	Entries         []*MappingEntryConfiguration
+	Default         interface{}
+	FailOnUnmatched bool
+	CaseInsensitive bool
 }
 
+func (c *MappingConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+	if len(c.Name) == 0 {
+		errs.add(keyPath(path, "name"), "invalid mapping name")
+	}
+
+	if c.Entries == nil {
+		errs.add(keyPath(path, "entries"), "uninitialized mapping entries collection")
+	}
+
+	for index, entry := range c.Entries {
+		// NOTE: Inner mapping entry config values validation
+		errs = append(errs, entry.validate(indexPath(path, "entries", index))...)
+	}
+
+	return errs
+}
+
+type MappingEntryConfiguration struct {
+	Match string
+	Regex string
+	Value interface{}
+}
+
+func (c *MappingEntryConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+	if len(c.Match) != 0 && len(c.Regex) != 0 {
+		errs.add(keyPath(path, "regex"), "ambiguous mapping entry match and regex values")
+	}
+
+	if _, err := regexp.Compile(c.Regex); err != nil {
+		errs.add(keyPath(path, "regex"), "invalid mapping entry regex that could not be parsed")
+	}
+
+	return errs
+}
+
+type MissingValuesStrategy int
+
+const (
+	NullMissingValues MissingValuesStrategy = iota
+	SkipMissingValues
+)
+
 type EndpointConfiguration struct {
-	Name   string
-	Values []*EndpointValueConfiguration
+	Name          string
+	Values        []*EndpointValueConfiguration
+	MissingValues MissingValuesStrategy
+	Metadata      bool
+
+	// NOTE: The nesting is opt-in, as it changes the output of the existing endpoint value names containing dots
+	NestedValues bool
 }
 
-func (c *EndpointConfiguration) isValid() (bool, string) {
+func (c *EndpointConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
 	if len(c.Name) == 0 {
-		return false, "invalid endpoint name"
+		errs.add(keyPath(path, "name"), "invalid endpoint name")
 	}
 
-	return true, ""
+	return errs
 }
 
 type EndpointValueConfiguration struct {
 	Name            string
 	SourceName      string
 	SourceValueName string
+	Expression      string
+	Hidden          bool
+}
+
+func (c *EndpointValueConfiguration) IsComputed() bool {
+	return len(c.Expression) != 0
 }
 
-func (c *EndpointValueConfiguration) isValid() (bool, string) {
+func (c *EndpointValueConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
 	if len(c.Name) == 0 {
-		return false, "invalid endpoint value name"
+		errs.add(keyPath(path, "name"), "invalid endpoint value name")
+	}
+
+	if c.IsComputed() {
+		if len(c.SourceName) != 0 || len(c.SourceValueName) != 0 {
+			errs.add(keyPath(path, "expression"), "ambiguous endpoint value expression and source values")
+		}
+
+		return errs
 	}
 
 	if len(c.SourceName) == 0 {
-		return false, "invalid endpoint value source name"
+		errs.add(keyPath(path, "source-name"), "invalid endpoint value source name")
 	}
 
 	if len(c.SourceValueName) == 0 {
-		return false, "invalid endpoint value source value name"
+		errs.add(keyPath(path, "source-value-name"), "invalid endpoint value source value name")
 	}
 
-	return true, ""
+	return errs
 }
 
 type SourceConfiguration struct {
@@ -141,28 +294,29 @@ type SourceConfiguration struct {
 	Values                 []*SourceValueConfiguration
 }
 
-func (c *SourceConfiguration) isValid() (bool, string) {
+func (c *SourceConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
 	if len(c.Name) == 0 {
-		return false, "invalid source name"
+		errs.add(keyPath(path, "name"), "invalid source name")
 	}
 
 	if _, err := url.Parse(c.Url); err != nil {
-		return false, "invalid source url"
+		errs.add(keyPath(path, "url"), "invalid source url")
 	}
 
 	if c.CachingLifeTimeSeconds < 0 {
-		return false, "invalid caching life time that is out of range"
+		errs.add(keyPath(path, "caching-life-time-seconds"), "invalid caching life time that is out of range")
 	}
 
 	if c.Retries < 0 {
-		return false, "invalid retries count that is out of range"
+		errs.add(keyPath(path, "retries-count"), "invalid retries count that is out of range")
 	}
 
 	if c.TimeoutSeconds < 0 {
-		return false, "invalid timeout seconds that is out of range"
+		errs.add(keyPath(path, "timeout-seconds"), "invalid timeout seconds that is out of range")
 	}
 
-	return true, ""
+	return errs
 }
 
 type VariableType int
@@ -171,6 +325,14 @@ const (
 	String VariableType = iota
 	Int
 	Float
+	Int64
+	Uint64
+	Bool
+	Timestamp
+	Duration
+	Ip
+	Cidr
+	Mac
 )
 
 type ExtractionStrategy int
@@ -178,34 +340,477 @@ type ExtractionStrategy int
 const (
 	First ExtractionStrategy = iota
 	Single
+	All
+)
+
+type ExtractionMode int
+
+const (
+	Text ExtractionMode = iota
+	InnerHtml
+	OuterHtml
+	OwnText
+)
+
+type UnitNormalization int
+
+const (
+	NoUnitNormalization UnitNormalization = iota
+	BytesUnitNormalization
+	BinaryBytesUnitNormalization
+)
+
+type TransformType int
+
+const (
+	TrimTransform TransformType = iota
+	ReplaceTransform
+	RegexExtractTransform
+	RegexReplaceTransform
+	LowerTransform
+	UpperTransform
+	SplitTransform
+	UnicodeNormalizeTransform
+	StripPrefixTransform
+	StripSuffixTransform
+	MultiplyTransform
+	OffsetTransform
+)
+
+type SourceValueTransformConfiguration struct {
+	Type        TransformType
+	Old         string
+	New         string
+	Regex       string
+	RegexIndex  int
+	Replacement string
+	Separator   string
+	Index       int
+	Value       string
+	Operand     float64
+}
+
+func (c *SourceValueTransformConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+	switch c.Type {
+	case TrimTransform, LowerTransform, UpperTransform, UnicodeNormalizeTransform:
+	case ReplaceTransform:
+		if len(c.Old) == 0 {
+			errs.add(keyPath(path, "old"), "invalid replace transform old value")
+		}
+	case RegexExtractTransform:
+		regex, err := regexp.Compile(c.Regex)
+		if err != nil || len(c.Regex) == 0 {
+			errs.add(keyPath(path, "regex"), "invalid regex extract transform regex that could not be parsed")
+		} else if c.RegexIndex < 0 || c.RegexIndex > regex.NumSubexp() {
+			errs.add(keyPath(path, "regex-match-index"), "invalid regex extract transform match index that is out of range")
+		}
+	case RegexReplaceTransform:
+		if _, err := regexp.Compile(c.Regex); err != nil || len(c.Regex) == 0 {
+			errs.add(keyPath(path, "regex"), "invalid regex replace transform regex that could not be parsed")
+		}
+	case SplitTransform:
+		if len(c.Separator) == 0 {
+			errs.add(keyPath(path, "separator"), "invalid split transform separator")
+		}
+	case StripPrefixTransform, StripSuffixTransform:
+		if len(c.Value) == 0 {
+			errs.add(keyPath(path, "value"), "invalid strip transform value")
+		}
+	case MultiplyTransform, OffsetTransform:
+		if math.IsNaN(c.Operand) || math.IsInf(c.Operand, 0) {
+			errs.add(keyPath(path, "operand"), "invalid arithmetic transform operand")
+		}
+	default:
+		errs.add(keyPath(path, "type"), "invalid transform type")
+	}
+
+	return errs
+}
+
+type SourceValueConstraintsConfiguration struct {
+	Min           *float64
+	Max           *float64
+	AllowedValues []string
+	Pattern       string
+	MinLength     *int
+	MaxLength     *int
+	Monotonic     bool
+
+	// NOTE: A decrease of a monotonic value is accepted as a counter reset (e.g. a device reboot) instead of a violation
+	ResetOnDecrease bool
+}
+
+func (c *SourceValueConstraintsConfiguration) IsNumeric() bool {
+	return c.Min != nil || c.Max != nil || c.Monotonic
+}
+
+func (c *SourceValueConstraintsConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+	if c.Min != nil && c.Max != nil && *c.Min > *c.Max {
+		errs.add(keyPath(path, "min"), "invalid constraint min value that is greater than the max value")
+	}
+
+	if _, err := regexp.Compile(c.Pattern); err != nil {
+		errs.add(keyPath(path, "pattern"), "invalid constraint pattern that could not be parsed")
+	}
+
+	if c.MinLength != nil && *c.MinLength < 0 {
+		errs.add(keyPath(path, "min-length"), "invalid constraint min length that is out of range")
+	}
+
+	if c.MaxLength != nil && (*c.MaxLength < 0 || (c.MinLength != nil && *c.MaxLength < *c.MinLength)) {
+		errs.add(keyPath(path, "max-length"), "invalid constraint max length that is out of range")
+	}
+
+	if c.ResetOnDecrease && !c.Monotonic {
+		errs.add(keyPath(path, "reset-on-decrease"), "reset on decrease requires the monotonic constraint")
+	}
+
+	return errs
+}
+
+type ValueKind int
+
+const (
+	Scalar ValueKind = iota
+	Table
 )
 
 type SourceValueConfiguration struct {
-	Name                 string
-	Xpath                string
-	ExtractionStrategy   ExtractionStrategy
-	ExtractionTrim       bool
-	ExtractionRegex      string
-	ExtractionRegexIndex int
-	Type                 VariableType
+	Name                    string
+	Xpath                   string
+	CssSelector             string
+	ExtractionStrategy      ExtractionStrategy
+	ExtractionMode          ExtractionMode
+	ExtractionAttribute     string
+	ExtractionStyleProperty string
+	ExtractionJoin          bool
+	ExtractionJoinSeparator string
+	ExtractionMinCount      int
+	ExtractionMaxCount      int
+	ExtractionTrim          bool
+	ExtractionRegex         string
+	ExtractionRegexIndex    int
+	ExtractionRegexGroups   []*SourceValueRegexGroupConfiguration
+	Type                    VariableType
+	BoolTruthyValues        []string
+	BoolFalsyValues         []string
+	TimestampLayout         string
+	TimestampTimeZone       string
+	NumberDecimalSeparator  string
+	NumberGroupingSeparator string
+	NumberStripUnit         bool
+	NumberAllowPrefixes     bool
+	NumberUnitNormalization UnitNormalization
+	Transforms              []*SourceValueTransformConfiguration
+	Mapping                 string
+	Required                bool
+	Default                 interface{}
+	HasDefault              bool
+	Constraints             *SourceValueConstraintsConfiguration
+	Unit                    string
+	Kind                    ValueKind
+	Table                   *SourceValueTableConfiguration
 }
 
-func (c *SourceValueConfiguration) isValid() (bool, string) {
+func (c *SourceValueConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
 	if len(c.Name) == 0 {
-		return false, "invalid source value name"
+		errs.add(keyPath(path, "name"), "invalid source value name")
+	}
+
+	if len(c.Xpath) == 0 && len(c.CssSelector) == 0 {
+		errs.add(keyPath(path, "xpath"), "missing xpath or css selector value")
 	}
 
-	if len(c.Xpath) == 0 {
-		return false, "invalid xpath value"
+	if len(c.Xpath) != 0 && len(c.CssSelector) != 0 {
+		errs.add(keyPath(path, "css-selector"), "ambiguous xpath and css selector values")
 	}
 
-	if _, err := regexp.Compile(c.ExtractionRegex); err != nil {
-		return false, "invalid regex that could not be parsed"
+	if len(c.Xpath) != 0 {
+		if _, err := xpath.Compile(c.Xpath); err != nil {
+			errs.add(keyPath(path, "xpath"), "invalid xpath that could not be compiled")
+		}
 	}
 
-	if c.ExtractionRegexIndex < 0 {
-		return false, "invalid regex match index that is out of range"
+	if len(c.CssSelector) != 0 {
+		if _, err := cascadia.Parse(c.CssSelector); err != nil {
+			errs.add(keyPath(path, "css-selector"), "invalid css selector that could not be parsed")
+		}
 	}
 
-	return true, ""
+	if len(c.ExtractionAttribute) != 0 && len(c.ExtractionStyleProperty) != 0 {
+		errs.add(keyPath(path, "extraction-style-property"), "ambiguous extraction attribute and style property values")
+	}
+
+	// NOTE: The text extraction mode is the default, so it can not be told apart from a mode that was not set
+	if c.ExtractionMode != Text && (len(c.ExtractionAttribute) != 0 || len(c.ExtractionStyleProperty) != 0) {
+		errs.add(keyPath(path, "extraction-mode"), "extraction mode is not used with the attribute and style property extraction")
+	}
+
+	if c.ExtractionStrategy != All && (c.ExtractionJoin || c.ExtractionMinCount != 0 || c.ExtractionMaxCount != 0) {
+		errs.add(keyPath(path, "extraction-strategy"), "join and cardinality options require the all extraction strategy")
+	}
+
+	if c.ExtractionMinCount < 0 {
+		errs.add(keyPath(path, "extraction-min-count"), "invalid extraction min count that is out of range")
+	}
+
+	if c.ExtractionMaxCount < 0 || (c.ExtractionMaxCount != 0 && c.ExtractionMaxCount < c.ExtractionMinCount) {
+		errs.add(keyPath(path, "extraction-max-count"), "invalid extraction max count that is out of range")
+	}
+
+	regex, regexErr := regexp.Compile(c.ExtractionRegex)
+	if regexErr != nil {
+		errs.add(keyPath(path, "extraction-regex"), "invalid regex that could not be parsed")
+	}
+
+	// NOTE: The match index zero refers to the whole match and the following indexes to the regex capture groups
+	if c.ExtractionRegexIndex < 0 || (regexErr == nil && c.ExtractionRegexIndex > regex.NumSubexp()) {
+		errs.add(keyPath(path, "extraction-regex-match-index"), "invalid regex match index that is out of range")
+	}
+
+	if len(c.ExtractionRegexGroups) != 0 {
+		if len(c.ExtractionRegex) == 0 {
+			errs.add(keyPath(path, "extraction-regex"), "regex groups require the extraction regex")
+		}
+
+		if c.ExtractionRegexIndex != 0 {
+			errs.add(keyPath(path, "extraction-regex-match-index"), "ambiguous regex groups and regex match index values")
+		}
+
+		if c.ExtractionStrategy == All || c.Kind != Scalar {
+			errs.add(keyPath(path, "extraction-regex-groups"), "regex groups require a scalar value extracted from a single element")
+		}
+
+		if len(c.Mapping) != 0 || c.Constraints != nil {
+			errs.add(keyPath(path, "extraction-regex-groups"), "regex groups do not support mappings and constraints")
+		}
+
+		// NOTE: Regex group names unique validation
+		groupNames := utils.NewEmptySet[string]()
+		for index, group := range c.ExtractionRegexGroups {
+			groupPath := indexPath(path, "extraction-regex-groups", index)
+
+			errs = append(errs, group.validate(groupPath)...)
+
+			if !groupNames.Add(group.Name) {
+				errs.add(keyPath(groupPath, "name"), "duplicate regex group name found")
+			}
+
+			// NOTE: The capture groups are only known when the regex could be parsed
+			if regexErr == nil && len(group.Name) != 0 && regex.SubexpIndex(group.Name) == -1 {
+				errs.add(keyPath(groupPath, "name"), "regex group references non existing named capture group")
+			}
+		}
+	}
+
+	if c.Constraints != nil {
+		constraintsPath := keyPath(path, "constraints")
+
+		// NOTE: Inner constraints config values validation
+		errs = append(errs, c.Constraints.validate(constraintsPath)...)
+
+		if c.Constraints.IsNumeric() {
+			numeric := len(c.Mapping) == 0 && c.Kind == Scalar && !(c.ExtractionStrategy == All && c.ExtractionJoin)
+
+			switch c.Type {
+			case Int, Int64, Uint64, Float, Duration:
+			default:
+				numeric = false
+			}
+
+			if !numeric {
+				errs.add(constraintsPath, "numeric constraints require a numeric value type")
+			}
+		}
+
+		if c.Constraints.Monotonic && c.ExtractionStrategy == All {
+			errs.add(keyPath(constraintsPath, "monotonic"), "monotonic constraint does not support the all extraction strategy")
+		}
+	}
+
+	if c.Required && c.HasDefault {
+		errs.add(keyPath(path, "default"), "default value requires the source value to be optional")
+	}
+
+	if _, ok := c.TypedDefault(); !ok {
+		errs.add(keyPath(path, "default"), "default value does not match the source value type")
+	}
+
+	for index, transform := range c.Transforms {
+		// NOTE: Inner transform config values validation
+		errs = append(errs, transform.validate(indexPath(path, "transforms", index))...)
+	}
+
+	if _, err := time.LoadLocation(c.TimestampTimeZone); err != nil {
+		errs.add(keyPath(path, "type.time-zone"), "invalid timestamp time zone")
+	}
+
+	if utf8.RuneCountInString(c.NumberDecimalSeparator) > 1 {
+		errs.add(keyPath(path, "type.decimal-separator"), "invalid number decimal separator that is longer than a single character")
+	}
+
+	if utf8.RuneCountInString(c.NumberGroupingSeparator) > 1 {
+		errs.add(keyPath(path, "type.grouping-separator"), "invalid number grouping separator that is longer than a single character")
+	}
+
+	if len(c.NumberGroupingSeparator) != 0 && (c.NumberGroupingSeparator == c.NumberDecimalSeparator || (len(c.NumberDecimalSeparator) == 0 && c.NumberGroupingSeparator == ".")) {
+		errs.add(keyPath(path, "type.grouping-separator"), "ambiguous number decimal and grouping separators")
+	}
+
+	for _, truthyValue := range c.BoolTruthyValues {
+		for _, falsyValue := range c.BoolFalsyValues {
+			if strings.EqualFold(truthyValue, falsyValue) {
+				errs.add(keyPath(path, "type.falsy-values"), "ambiguous bool truthy and falsy values")
+			}
+		}
+	}
+
+	switch c.Kind {
+	case Scalar:
+		if c.Table != nil {
+			errs.add(keyPath(path, "table"), "table options require the table value kind")
+		}
+	case Table:
+		if c.Table == nil {
+			errs.add(keyPath(path, "table"), "missing table options for the table value kind")
+		}
+
+		if c.ExtractionStrategy == All {
+			errs.add(keyPath(path, "extraction-strategy"), "table value kind does not support the all extraction strategy")
+		}
+
+		if len(c.ExtractionAttribute) != 0 || len(c.ExtractionStyleProperty) != 0 {
+			errs.add(keyPath(path, "kind"), "table value kind does not support attribute and style property extraction")
+		}
+
+		// NOTE: Inner table config values validation
+		if c.Table != nil {
+			errs = append(errs, c.Table.validate(keyPath(path, "table"))...)
+		}
+	default:
+		errs.add(keyPath(path, "kind"), "invalid value kind")
+	}
+
+	return errs
+}
+
+// Create the configuration of a value emitted by the given named regex group. The group value inherits the parent value options
+func (c *SourceValueConfiguration) RegexGroupValue(name string) (*SourceValueConfiguration, bool) {
+	for _, group := range c.ExtractionRegexGroups {
+		if group.Name != name {
+			continue
+		}
+
+		groupValue := *c
+		groupValue.Name = group.Name
+		groupValue.Type = group.Type
+		groupValue.ExtractionRegexGroups = nil
+
+		return &groupValue, true
+	}
+
+	return nil, false
+}
+
+type SourceValueRegexGroupConfiguration struct {
+	Name string
+	Type VariableType
+}
+
+func (c *SourceValueRegexGroupConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+	if len(c.Name) == 0 {
+		errs.add(keyPath(path, "name"), "invalid regex group name")
+	}
+
+	return errs
+}
+
+type SourceValueTableConfiguration struct {
+	HeaderRow      bool
+	SkipHeaderRows int
+	SkipFooterRows int
+	Columns        []*SourceValueTableColumnConfiguration
+	RowFilters     []*SourceValueTableRowFilterConfiguration
+}
+
+func (c *SourceValueTableConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+	if c.SkipHeaderRows < 0 {
+		errs.add(keyPath(path, "skip-header-rows"), "invalid table skip header rows count that is out of range")
+	}
+
+	if c.SkipFooterRows < 0 {
+		errs.add(keyPath(path, "skip-footer-rows"), "invalid table skip footer rows count that is out of range")
+	}
+
+	if len(c.Columns) == 0 && !c.HeaderRow {
+		errs.add(keyPath(path, "columns"), "table requires either columns or a header row")
+	}
+
+	// NOTE: Table column names unique validation
+	columnNames := utils.NewEmptySet[string]()
+	for index, column := range c.Columns {
+		columnPath := indexPath(path, "columns", index)
+
+		errs = append(errs, column.validate(columnPath)...)
+
+		if !columnNames.Add(column.Name) {
+			errs.add(keyPath(columnPath, "name"), "duplicate table column name found")
+		}
+	}
+
+	for index, rowFilter := range c.RowFilters {
+		rowFilterPath := indexPath(path, "row-filters", index)
+
+		errs = append(errs, rowFilter.validate(rowFilterPath)...)
+
+		// NOTE: Column names are only known ahead of time when they are configured explicitly
+		if len(c.Columns) != 0 && !columnNames.Contains(rowFilter.Column) {
+			errs.add(keyPath(rowFilterPath, "column"), "table row filter references non existing column")
+		}
+	}
+
+	return errs
+}
+
+type SourceValueTableColumnConfiguration struct {
+	Name  string
+	Index int
+	Type  VariableType
+}
+
+func (c *SourceValueTableColumnConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+	if len(c.Name) == 0 {
+		errs.add(keyPath(path, "name"), "invalid table column name")
+	}
+
+	if c.Index < 0 {
+		errs.add(keyPath(path, "index"), "invalid table column index that is out of range")
+	}
+
+	return errs
+}
+
+type SourceValueTableRowFilterConfiguration struct {
+	Column  string
+	Regex   string
+	Exclude bool
+}
+
+func (c *SourceValueTableRowFilterConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
+	if len(c.Column) == 0 {
+		errs.add(keyPath(path, "column"), "invalid table row filter column name")
+	}
+
+	if _, err := regexp.Compile(c.Regex); err != nil {
+		errs.add(keyPath(path, "regex"), "invalid table row filter regex that could not be parsed")
+	}
+
+	return errs
 }
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceValueConfigurationShouldValidateRegexGroups(t *testing.T) {
+	cases := []struct {
+		regex  string
+		groups []*SourceValueRegexGroupConfiguration
+		valid  bool
+	}{
+		{regex: `Up: (?P<up>[\d.]+) Mbps / Down: (?P<down>[\d.]+) Mbps`, groups: []*SourceValueRegexGroupConfiguration{{Name: "up", Type: Float}, {Name: "down", Type: Float}}, valid: true},
+		{regex: `Up: (?P<up>[\d.]+) Mbps`, groups: []*SourceValueRegexGroupConfiguration{{Name: "up", Type: Float}, {Name: "down", Type: Float}}, valid: false},
+		{regex: `Up: (?P<up>[\d.]+) Mbps`, groups: []*SourceValueRegexGroupConfiguration{{Name: "up", Type: Float}, {Name: "up", Type: Int}}, valid: false},
+		{regex: "", groups: []*SourceValueRegexGroupConfiguration{{Name: "up", Type: Float}}, valid: false},
+	}
+
+	for _, c := range cases {
+		value := &SourceValueConfiguration{
+			Name:                  "bandwidth",
+			Xpath:                 "//td",
+			ExtractionRegex:       c.regex,
+			ExtractionRegexGroups: c.groups,
+			Required:              true,
+		}
+
+		errs := value.validate("general.sources[0].values[0]")
+		assert.Equal(t, c.valid, len(errs) == 0)
+	}
+}
+
+func TestSourceValueConfigurationShouldValidateAllExtractionOptions(t *testing.T) {
+	cases := []struct {
+		strategy ExtractionStrategy
+		join     bool
+		minCount int
+		maxCount int
+		valid    bool
+	}{
+		{strategy: All, join: true, minCount: 1, maxCount: 4, valid: true},
+		{strategy: All, join: false, minCount: 2, maxCount: 0, valid: true},
+		{strategy: All, join: false, minCount: 4, maxCount: 4, valid: true},
+		{strategy: All, join: false, minCount: -1, maxCount: 0, valid: false},
+		{strategy: All, join: false, minCount: 0, maxCount: -1, valid: false},
+		{strategy: All, join: false, minCount: 4, maxCount: 2, valid: false},
+		{strategy: First, join: true, minCount: 0, maxCount: 0, valid: false},
+		{strategy: Single, join: false, minCount: 1, maxCount: 0, valid: false},
+	}
+
+	for _, c := range cases {
+		value := &SourceValueConfiguration{
+			Name:               "dns",
+			Xpath:              "//li",
+			ExtractionStrategy: c.strategy,
+			ExtractionJoin:     c.join,
+			ExtractionMinCount: c.minCount,
+			ExtractionMaxCount: c.maxCount,
+			Required:           true,
+		}
+
+		errs := value.validate("general.sources[0].values[0]")
+		assert.Equal(t, c.valid, len(errs) == 0)
+	}
+}
+
+func TestRegexGroupValueShouldInheritParentOptions(t *testing.T) {
+	value := &SourceValueConfiguration{
+		Name:                   "bandwidth",
+		NumberDecimalSeparator: ",",
+		ExtractionRegexGroups:  []*SourceValueRegexGroupConfiguration{{Name: "up", Type: Float}},
+	}
+
+	groupValue, ok := value.RegexGroupValue("up")
+	assert.True(t, ok)
+	assert.Equal(t, "up", groupValue.Name)
+	assert.Equal(t, Float, groupValue.Type)
+	assert.Equal(t, ",", groupValue.NumberDecimalSeparator)
+	assert.Nil(t, groupValue.ExtractionRegexGroups)
+
+	_, ok = value.RegexGroupValue("down")
+	assert.False(t, ok)
+}
+
+func TestSourceValueConfigurationShouldRejectExtractionModeWithAttributeOrStyleProperty(t *testing.T) {
+	cases := []struct {
+		mode          ExtractionMode
+		attribute     string
+		styleProperty string
+		valid         bool
+	}{
+		{mode: Text, attribute: "title", valid: true},
+		{mode: Text, styleProperty: "width", valid: true},
+		{mode: InnerHtml, valid: true},
+		{mode: InnerHtml, attribute: "title", valid: false},
+		{mode: OwnText, styleProperty: "width", valid: false},
+	}
+
+	for _, c := range cases {
+		value := &SourceValueConfiguration{
+			Name:                    "signal",
+			Xpath:                   "//div",
+			ExtractionMode:          c.mode,
+			ExtractionAttribute:     c.attribute,
+			ExtractionStyleProperty: c.styleProperty,
+			Required:                true,
+		}
+
+		errs := value.validate("general.sources[0].values[0]")
+		assert.Equal(t, c.valid, len(errs) == 0)
+	}
+}
@@ -0,0 +1,215 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/ast"
+	"github.com/expr-lang/expr/vm"
+)
+
+// Compile a endpoint value expression against a environment of endpoint value names mapped to values of the expected types
+func CompileExpression(expression string, env map[string]interface{}) (*vm.Program, error) {
+	if len(expression) == 0 {
+		return nil, fmt.Errorf("config: invalid empty expression provided")
+	}
+
+	if program, err := expr.Compile(expression, expr.Env(env)); err != nil {
+		return nil, fmt.Errorf("config: failed to compile the expression: %w", err)
+	} else {
+		return program, nil
+	}
+}
+
+// Find the names of the environment values that are referenced by the compiled expression
+func ExpressionInputs(program *vm.Program, env map[string]interface{}) []string {
+	visitor := &expressionInputsVisitor{env: env, inputs: make([]string, 0)}
+
+	node := program.Node()
+	ast.Walk(&node, visitor)
+
+	return visitor.inputs
+}
+
+type expressionInputsVisitor struct {
+	env    map[string]interface{}
+	inputs []string
+}
+
+func (v *expressionInputsVisitor) Visit(node *ast.Node) {
+	identifier, ok := (*node).(*ast.IdentifierNode)
+	if !ok {
+		return
+	}
+
+	if _, ok := v.env[identifier.Value]; ok && !slices.Contains(v.inputs, identifier.Value) {
+		v.inputs = append(v.inputs, identifier.Value)
+	}
+}
+
+// Create a expression environment of the endpoint source backed values names mapped to zero values of their types
+func (c *ApiKitConfiguration) CreateExpressionEnv(endpoint *EndpointConfiguration) (map[string]interface{}, error) {
+	env := make(map[string]interface{}, len(endpoint.Values))
+	for _, value := range endpoint.Values {
+		if value.IsComputed() {
+			continue
+		}
+
+		sourceValue, ok := c.FindSourceValue(value.SourceName, value.SourceValueName)
+		if !ok {
+			return nil, fmt.Errorf("config: endpoint value references non existing source value")
+		}
+
+		env[value.Name] = sourceValue.SampleValue()
+	}
+
+	return env, nil
+}
+
+// Find the source value configuration by the source name and the source value name
+func (c *ApiKitConfiguration) FindSourceValue(sourceName, sourceValueName string) (*SourceValueConfiguration, bool) {
+	for _, source := range c.Sources {
+		if source.Name != sourceName {
+			continue
+		}
+
+		for _, value := range source.Values {
+			if value.Name == sourceValueName {
+				return value, true
+			}
+
+			if groupValue, ok := value.RegexGroupValue(sourceValueName); ok {
+				return groupValue, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+// Create a zero value of the Go type that the source value will be represented with after extraction
+func (c *SourceValueConfiguration) SampleValue() interface{} {
+	if len(c.Mapping) != 0 {
+		return nil
+	}
+
+	if len(c.ExtractionRegexGroups) != 0 {
+		sample := make(map[string]interface{}, len(c.ExtractionRegexGroups))
+		for _, group := range c.ExtractionRegexGroups {
+			groupValue, _ := c.RegexGroupValue(group.Name)
+			sample[group.Name] = groupValue.SampleValue()
+		}
+
+		return sample
+	}
+
+	if c.Kind == Table {
+		return []interface{}{}
+	}
+
+	if c.ExtractionStrategy == All {
+		if c.ExtractionJoin {
+			return ""
+		}
+
+		return []interface{}{}
+	}
+
+	switch c.Type {
+	case Int:
+		return int(0)
+	case Float:
+		return float64(0)
+	case Int64, Duration:
+		return int64(0)
+	case Uint64:
+		return uint64(0)
+	case Bool:
+		return false
+	default:
+		return ""
+	}
+}
+
+// Convert the default value to the Go type that the source value is represented with after extraction, so the default
+// can be used in place of the extracted value (e.g. in the computed values expressions). The default is not valid if it
+// does not match the value type. The default of a value with regex groups is checked against the type of every group
+func (c *SourceValueConfiguration) TypedDefault() (interface{}, bool) {
+	if c.Default == nil {
+		return nil, true
+	}
+
+	if len(c.ExtractionRegexGroups) != 0 {
+		for _, group := range c.ExtractionRegexGroups {
+			groupValue, _ := c.RegexGroupValue(group.Name)
+			if _, ok := groupValue.TypedDefault(); !ok {
+				return nil, false
+			}
+		}
+
+		return c.Default, true
+	}
+
+	switch c.SampleValue().(type) {
+	case nil:
+		return c.Default, true
+	case int:
+		if number, ok := integerDefault(c.Default, math.MinInt, math.MaxInt); ok {
+			return int(number), true
+		}
+	case int64:
+		if number, ok := integerDefault(c.Default, math.MinInt64, math.MaxInt64); ok {
+			return int64(number), true
+		}
+	case uint64:
+		if number, ok := integerDefault(c.Default, 0, math.MaxUint64); ok {
+			return uint64(number), true
+		}
+	case float64:
+		if number, ok := numberDefault(c.Default); ok {
+			return number, true
+		}
+	case bool:
+		if value, ok := c.Default.(bool); ok {
+			return value, true
+		}
+	case string:
+		if value, ok := c.Default.(string); ok {
+			return value, true
+		}
+	case []interface{}:
+		if value, ok := c.Default.([]interface{}); ok {
+			return value, true
+		}
+	}
+
+	return nil, false
+}
+
+// NOTE: The decoded numbers are represented differently depending on the config file format (e.g. float64 for JSON)
+func numberDefault(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
+
+// NOTE: The float64 representations of the max integer values are out of range, so the upper bound is exclusive
+func integerDefault(value interface{}, min, max float64) (float64, bool) {
+	number, ok := numberDefault(value)
+	if !ok || number != math.Trunc(number) || number < min || number >= max {
+		return 0, false
+	}
+
+	return number, true
+}
@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompileExpressionShouldCheckTypes(t *testing.T) {
+	env := map[string]interface{}{
+		"rx":   (&SourceValueConfiguration{Type: Uint64}).SampleValue(),
+		"tx":   (&SourceValueConfiguration{Type: Uint64}).SampleValue(),
+		"temp": (&SourceValueConfiguration{Type: Float}).SampleValue(),
+		"link": (&SourceValueConfiguration{Type: String}).SampleValue(),
+	}
+
+	cases := []struct {
+		expression string
+		valid      bool
+	}{
+		{expression: "rx + tx", valid: true},
+		{expression: "rx / (rx + tx) * 100", valid: true},
+		{expression: `temp < 70 && link == "up"`, valid: true},
+		{expression: "link + 1", valid: false},
+		{expression: "temp && link", valid: false},
+		{expression: "missing + 1", valid: false},
+	}
+
+	for _, c := range cases {
+		_, err := CompileExpression(c.expression, env)
+
+		assert.Equal(t, c.valid, err == nil, c.expression)
+	}
+}
+
+func TestExpressionInputsShouldReturnReferencedValues(t *testing.T) {
+	env := map[string]interface{}{"rx": uint64(0), "tx": uint64(0), "link": ""}
+
+	program, err := CompileExpression(`link == "up" ? rx / (rx + tx) : len(link)`, env)
+	assert.Nil(t, err)
+
+	assert.ElementsMatch(t, []string{"link", "rx", "tx"}, ExpressionInputs(program, env))
+}
+
+func TestTypedDefaultShouldMatchTheValueType(t *testing.T) {
+	cases := []struct {
+		value    *SourceValueConfiguration
+		valid    bool
+		expected interface{}
+	}{
+		{value: &SourceValueConfiguration{Type: Int, Default: float64(5)}, valid: true, expected: 5},
+		{value: &SourceValueConfiguration{Type: Float, Default: 5}, valid: true, expected: 5.0},
+		{value: &SourceValueConfiguration{Type: Uint64, Default: int64(5)}, valid: true, expected: uint64(5)},
+		{value: &SourceValueConfiguration{Type: Duration, Default: 60}, valid: true, expected: int64(60)},
+		{value: &SourceValueConfiguration{Type: Bool, Default: true}, valid: true, expected: true},
+		{value: &SourceValueConfiguration{Type: Ip, Default: "0.0.0.0"}, valid: true, expected: "0.0.0.0"},
+		{value: &SourceValueConfiguration{Type: String, Default: nil}, valid: true, expected: nil},
+		{value: &SourceValueConfiguration{Type: Int, Mapping: "link", Default: "down"}, valid: true, expected: "down"},
+		{value: &SourceValueConfiguration{Type: String, ExtractionStrategy: All, Default: []interface{}{}}, valid: true, expected: []interface{}{}},
+		{value: &SourceValueConfiguration{Type: Int, Default: "5"}, valid: false},
+		{value: &SourceValueConfiguration{Type: Int, Default: 5.5}, valid: false},
+		{value: &SourceValueConfiguration{Type: Uint64, Default: -1}, valid: false},
+		{value: &SourceValueConfiguration{Type: String, Default: 5}, valid: false},
+		{value: &SourceValueConfiguration{Type: Bool, Default: "yes"}, valid: false},
+		{value: &SourceValueConfiguration{Type: String, ExtractionStrategy: All, Default: "none"}, valid: false},
+		{value: &SourceValueConfiguration{ExtractionRegexGroups: []*SourceValueRegexGroupConfiguration{{Name: "up", Type: Float}, {Name: "model", Type: String}}, Default: 0}, valid: false},
+	}
+
+	for _, c := range cases {
+		actual, ok := c.value.TypedDefault()
+
+		assert.Equal(t, c.valid, ok)
+		if c.valid {
+			assert.Equal(t, c.expected, actual)
+		}
+	}
+}
@@ -1,21 +1,60 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/Krzysztofz01/apikit/internal/utils"
+	"github.com/mitchellh/mapstructure"
 	"github.com/spf13/viper"
 )
 
 const (
-	configFileName string = "config"
-	configFileType string = "json"
+	ConfigFilePathEnv     string = "APIKIT_CONFIG"
+	defaultConfigFileName string = "config.json"
 )
 
 type apiKitConfiguration struct {
 	Sources   []*sourceConfiguration   `mapstructure:"sources"`
 	Endpoints []*endpointConfiguration `mapstructure:"endpoints"`
+	Mappings  []*mappingConfiguration  `mapstructure:"mappings"`
+
+	// NOTE: The source templates, groups and defaults are consumed by the template expansion before decoding
+	SourceDefaults  *sourceDefaultsConfiguration `mapstructure:"source-defaults"`
+	SourceGroups    []*sourceGroupConfiguration  `mapstructure:"source-groups"`
+	SourceTemplates []*sourceConfiguration       `mapstructure:"source-templates"`
+}
+
+type sourceDefaultsConfiguration struct {
+	CachingEnable          bool `mapstructure:"caching-enabled"`
+	CachingLifeTimeSeconds int  `mapstructure:"caching-life-time-seconds"`
+	Retries                int  `mapstructure:"retries-count"`
+	TimeoutSeconds         int  `mapstructure:"timeout-seconds"`
+}
+
+type sourceGroupConfiguration struct {
+	Name                   string `mapstructure:"name"`
+	CachingEnable          bool   `mapstructure:"caching-enabled"`
+	CachingLifeTimeSeconds int    `mapstructure:"caching-life-time-seconds"`
+	Retries                int    `mapstructure:"retries-count"`
+	TimeoutSeconds         int    `mapstructure:"timeout-seconds"`
+}
+
+type mappingConfiguration struct {
+	Name            string                       `mapstructure:"name"`
+	Entries         []*mappingEntryConfiguration `mapstructure:"entries"`
+	Default         interface{}                  `mapstructure:"default"`
+	FailOnUnmatched bool                         `mapstructure:"fail-on-unmatched"`
+	CaseInsensitive bool                         `mapstructure:"case-insensitive"`
+}
+
+type mappingEntryConfiguration struct {
+	Match string      `mapstructure:"match"`
+	Regex string      `mapstructure:"regex"`
+	Value interface{} `mapstructure:"value"`
 }
 
 type apiKitServerConfiguration struct {
@@ -24,6 +63,14 @@ type apiKitServerConfiguration struct {
 	ApiKeys     []*apiKitServerKeyConfiguration      `mapstructure:"api-keys"`
 	VerboseMode bool                                 `mapstructure:"verbose-mode"`
 	Host        string                               `mapstructure:"host"`
+	Include     []string                             `mapstructure:"include"`
+	Version     int                                  `mapstructure:"version"`
+	Admin       *apiKitServerAdminConfiguration      `mapstructure:"admin"`
+}
+
+type apiKitServerAdminConfiguration struct {
+	Enabled            bool     `mapstructure:"enabled"`
+	RequiredApiKeyPool []string `mapstructure:"required-api-key-name-pool"`
 }
 
 type apiKitServerKeyConfiguration struct {
@@ -38,14 +85,19 @@ type apiKitServerEndpointConfiguration struct {
 }
 
 type endpointConfiguration struct {
-	Name   string                        `mapstructure:"name"`
-	Values []*endpointValueConfiguration `mapstructure:"values"`
+	Name          string                        `mapstructure:"name"`
+	Values        []*endpointValueConfiguration `mapstructure:"values"`
+	MissingValues string                        `mapstructure:"missing-values"`
+	Metadata      bool                          `mapstructure:"metadata"`
+	NestedValues  bool                          `mapstructure:"nested-values"`
 }
 
 type endpointValueConfiguration struct {
 	Name            string `mapstructure:"name"`
 	SourceName      string `mapstructure:"source-name"`
 	SourceValueName string `mapstructure:"source-value-name"`
+	Expression      string `mapstructure:"expression"`
+	Hidden          bool   `mapstructure:"hidden"`
 }
 
 type sourceConfiguration struct {
@@ -57,42 +109,433 @@ type sourceConfiguration struct {
 	HttpHeader             map[string]string           `mapstructure:"http-headers"`
 	TimeoutSeconds         int                         `mapstructure:"timeout-seconds"`
 	Values                 []*sourceValueConfiguration `mapstructure:"values"`
+
+	// NOTE: The template instantiation options are consumed by the template expansion before decoding
+	Template   string                 `mapstructure:"template"`
+	Group      string                 `mapstructure:"group"`
+	Parameters map[string]interface{} `mapstructure:"parameters"`
 }
 
 type sourceValueConfiguration struct {
-	Name                 string `mapstructure:"name"`
-	Xpath                string `mapstructure:"xpath"`
-	ExtractionStrategy   string `mapstructure:"extraction-strategy"`
-	ExtractionTrim       bool   `mapstructure:"extraction-trim"`
-	ExtractionRegex      string `mapstructure:"extraction-regex"`
-	ExtractionRegexIndex int    `mapstructure:"extraction-regex-match-index"`
-	Type                 string `mapstructure:"type"`
+	Name                    string                                `mapstructure:"name"`
+	Xpath                   string                                `mapstructure:"xpath"`
+	CssSelector             string                                `mapstructure:"css-selector"`
+	ExtractionStrategy      string                                `mapstructure:"extraction-strategy"`
+	ExtractionMode          string                                `mapstructure:"extraction-mode"`
+	ExtractionAttribute     string                                `mapstructure:"extraction-attribute"`
+	ExtractionStyleProperty string                                `mapstructure:"extraction-style-property"`
+	ExtractionJoin          bool                                  `mapstructure:"extraction-join"`
+	ExtractionJoinSeparator string                                `mapstructure:"extraction-join-separator"`
+	ExtractionMinCount      int                                   `mapstructure:"extraction-min-count"`
+	ExtractionMaxCount      int                                   `mapstructure:"extraction-max-count"`
+	ExtractionTrim          bool                                  `mapstructure:"extraction-trim"`
+	ExtractionRegex         string                                `mapstructure:"extraction-regex"`
+	ExtractionRegexIndex    int                                   `mapstructure:"extraction-regex-match-index"`
+	ExtractionRegexGroups   []*sourceValueRegexGroupConfiguration `mapstructure:"extraction-regex-groups"`
+	Type                    *sourceValueTypeConfiguration         `mapstructure:"type"`
+	Transforms              []*sourceValueTransformConfiguration  `mapstructure:"transforms"`
+	Mapping                 string                                `mapstructure:"mapping"`
+	Required                *bool                                 `mapstructure:"required"`
+	Default                 interface{}                           `mapstructure:"default"`
+	Constraints             *sourceValueConstraintsConfiguration  `mapstructure:"constraints"`
+	Unit                    string                                `mapstructure:"unit"`
+	Kind                    string                                `mapstructure:"kind"`
+	Table                   *sourceValueTableConfiguration        `mapstructure:"table"`
+
+	hasDefault bool
+}
+
+type sourceValueTypeConfiguration struct {
+	Name              string   `mapstructure:"name"`
+	TruthyValues      []string `mapstructure:"truthy-values"`
+	FalsyValues       []string `mapstructure:"falsy-values"`
+	Layout            string   `mapstructure:"layout"`
+	TimeZone          string   `mapstructure:"time-zone"`
+	DecimalSeparator  string   `mapstructure:"decimal-separator"`
+	GroupingSeparator string   `mapstructure:"grouping-separator"`
+	StripUnit         bool     `mapstructure:"strip-unit"`
+	AllowPrefixes     bool     `mapstructure:"allow-prefixes"`
+	UnitNormalization string   `mapstructure:"unit-normalization"`
+}
+
+type sourceValueConstraintsConfiguration struct {
+	Min             *float64 `mapstructure:"min"`
+	Max             *float64 `mapstructure:"max"`
+	AllowedValues   []string `mapstructure:"allowed-values"`
+	Pattern         string   `mapstructure:"pattern"`
+	MinLength       *int     `mapstructure:"min-length"`
+	MaxLength       *int     `mapstructure:"max-length"`
+	Monotonic       bool     `mapstructure:"monotonic"`
+	ResetOnDecrease bool     `mapstructure:"reset-on-decrease"`
+}
+
+type sourceValueRegexGroupConfiguration struct {
+	Name string `mapstructure:"name"`
+	Type string `mapstructure:"type"`
+}
+
+type sourceValueTransformConfiguration struct {
+	Type        string  `mapstructure:"type"`
+	Old         string  `mapstructure:"old"`
+	New         string  `mapstructure:"new"`
+	Regex       string  `mapstructure:"regex"`
+	RegexIndex  int     `mapstructure:"regex-match-index"`
+	Replacement string  `mapstructure:"replacement"`
+	Separator   string  `mapstructure:"separator"`
+	Index       int     `mapstructure:"index"`
+	Value       string  `mapstructure:"value"`
+	Operand     float64 `mapstructure:"operand"`
+}
+
+type sourceValueTableConfiguration struct {
+	HeaderRow      bool                                      `mapstructure:"header-row"`
+	SkipHeaderRows int                                       `mapstructure:"skip-header-rows"`
+	SkipFooterRows int                                       `mapstructure:"skip-footer-rows"`
+	Columns        []*sourceValueTableColumnConfiguration    `mapstructure:"columns"`
+	RowFilters     []*sourceValueTableRowFilterConfiguration `mapstructure:"row-filters"`
 }
 
-func LoadServerConfigurationFromFile() (*ApiKitServerConfiguration, error) {
+type sourceValueTableColumnConfiguration struct {
+	Name  string `mapstructure:"name"`
+	Index int    `mapstructure:"index"`
+	Type  string `mapstructure:"type"`
+}
+
+type sourceValueTableRowFilterConfiguration struct {
+	Column  string `mapstructure:"column"`
+	Regex   string `mapstructure:"regex"`
+	Exclude bool   `mapstructure:"exclude"`
+}
+
+// Resolve the configuration file path. The explicitly provided path takes precedence over the APIKIT_CONFIG environment
+// variable and the config.json file in the current working directory
+func ResolveConfigurationFilePath(path string) (string, error) {
+	if len(path) != 0 {
+		return path, nil
+	}
+
+	if envPath, ok := os.LookupEnv(ConfigFilePathEnv); ok && len(envPath) != 0 {
+		return envPath, nil
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
-		return nil, fmt.Errorf("config: failed to access the current working directory path: %w", err)
+		return "", fmt.Errorf("config: failed to access the current working directory path: %w", err)
+	}
+
+	return filepath.Join(cwd, defaultConfigFileName), nil
+}
+
+// Load the server configuration from the file at the given path. The file format is detected from the file extension.
+// The files matched by the include patterns are loaded and merged into the configuration
+func LoadServerConfigurationFromFile(path string) (*ApiKitServerConfiguration, error) {
+	files, err := readConfigurationFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := buildServerConfiguration(files)
+	if err != nil {
+		return nil, err
+	}
+
+	config.IncludeDirs = resolveIncludeDirectories(path, settingsStringSlice(files[0].settings, "include"))
+	return config, nil
+}
+
+// Read the config file at the given path and the config files matched by its include patterns
+func readConfigurationFiles(path string) ([]*configurationFile, error) {
+	mainFile, err := readConfigurationFile(path)
+	if err != nil {
+		return nil, err
 	}
 
-	viper.AddConfigPath(cwd)
-	viper.SetConfigName(configFileName)
-	viper.SetConfigType(configFileType)
+	includedPaths, err := resolveIncludePaths(path, settingsStringSlice(mainFile.settings, "include"))
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to resolve the included config files of %s: %w", path, err)
+	}
 
-	if err := viper.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("config: failed to read the config file: %w", err)
+	files := []*configurationFile{mainFile}
+	for _, includedPath := range includedPaths {
+		if includedFile, err := readConfigurationFile(includedPath); err != nil {
+			return nil, fmt.Errorf("config: failed to load the included config file: %w", err)
+		} else {
+			files = append(files, includedFile)
+		}
 	}
 
+	return files, nil
+}
+
+// Expand, decode, merge and validate the settings of the config files into a single server configuration
+func buildServerConfiguration(files []*configurationFile) (*ApiKitServerConfiguration, error) {
+	if err := expandSourceTemplates(files); err != nil {
+		return nil, fmt.Errorf("config: failed to expand the source templates: %w", err)
+	}
+
+	// NOTE: The validation errors of the config files are gathered in order to report the problems of all files at once
+	var decodeErrs ValidationErrors
+	fileConfigs := make([]*ApiKitServerConfiguration, 0, len(files))
+	for index, file := range files {
+		fileConfig, include, err := decodeConfigurationFile(file)
+		if err != nil {
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				return nil, err
+			}
+
+			decodeErrs = append(decodeErrs, errs...)
+			continue
+		}
+
+		if index != 0 && len(include) != 0 {
+			return nil, fmt.Errorf("config: nested includes are not supported in the included %s config file", file.path)
+		}
+
+		if index != 0 && len(fileConfig.Host) != 0 {
+			return nil, fmt.Errorf("config: the host can not be specified in the included %s config file", file.path)
+		}
+
+		if index != 0 && fileConfig.Admin != nil {
+			return nil, fmt.Errorf("config: the admin api can not be configured in the included %s config file", file.path)
+		}
+
+		fileConfigs = append(fileConfigs, fileConfig)
+	}
+
+	if len(decodeErrs) != 0 {
+		return nil, fmt.Errorf("config: validation failed: %w", decodeErrs)
+	}
+
+	config, err := mergeConfigurations(fileConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to merge the included config files: %w", err)
+	}
+
+	// NOTE: The configuration is validated after merging, as the included files can reference each other
+	if errs := ValidateServerErrors(config); len(errs) != 0 {
+		locateValidationErrors(errs, fileConfigs)
+		return nil, fmt.Errorf("config: validation failed: %w", errs)
+	}
+
+	config.Warnings = make(ValidationErrors, 0)
+	for _, file := range files {
+		config.Warnings = append(config.Warnings, file.warnings...)
+	}
+
+	warnings := ValidateServerWarnings(config)
+	locateValidationErrors(warnings, fileConfigs)
+	config.Warnings = append(config.Warnings, warnings...)
+
+	return config, nil
+}
+
+// Settings of a single config file. The raw settings are kept without the resolved references in order to allow
+// writing the config file back without exposing the secrets
+type configurationFile struct {
+	path     string
+	raw      map[string]interface{}
+	settings map[string]interface{}
+	secrets  []string
+	warnings ValidationErrors
+}
+
+func readConfigurationFile(path string) (*configurationFile, error) {
+	settings, err := readConfigurationSettings(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: The config files using the layout of a previous version are migrated before the references are resolved
+	version, changes, err := migrateSettings(settings)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to migrate the %s config file: %w", path, err)
+	}
+
+	var warnings ValidationErrors
+	if len(changes) != 0 {
+		warnings = ValidationErrors{{
+			File:    path,
+			Path:    "version",
+			Message: fmt.Sprintf("deprecated config version %d layout migrated with %d change(s), run the config migrate command to update the file", version, len(changes)),
+		}}
+	}
+
+	return createConfigurationFile(path, settings, warnings)
+}
+
+func createConfigurationFile(path string, raw map[string]interface{}, warnings ValidationErrors) (*configurationFile, error) {
+	// NOTE: The settings are resolved on a copy, as the references are resolved and the templates expanded in place
+	secrets := utils.NewEmptySet[string]()
+	settings, err := interpolateSettings(copySettings(raw), "", false, secrets)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to resolve the %s config file references: %w", path, err)
+	}
+
+	return &configurationFile{
+		path:     path,
+		raw:      raw,
+		settings: settings.(map[string]interface{}),
+		secrets:  secrets.Elements(),
+		warnings: warnings,
+	}, nil
+}
+
+// Create a deep copy of the maps and slices of the raw settings
+func copySettings(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			result[key] = copySettings(child)
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, child := range v {
+			result = append(result, copySettings(child))
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// Read the raw settings of the config file without resolving the references
+func readConfigurationSettings(path string) (map[string]interface{}, error) {
+	var configType string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		configType = "json"
+	case ".yaml", ".yml":
+		configType = "yaml"
+	case ".toml":
+		configType = "toml"
+	default:
+		return nil, fmt.Errorf("config: unsupported config file extension of %s", path)
+	}
+
+	// NOTE: A dedicated viper instance is used in order to allow loading multiple configurations in one process
+	v := viper.New()
+	v.SetConfigFile(path)
+	v.SetConfigType(configType)
+
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("config: failed to read the %s config file: %w", path, err)
+	}
+
+	return v.AllSettings(), nil
+}
+
+// Write the settings to the config file using the format of the file extension. The settings are written into a
+// temporary file in the same directory, which then replaces the config file, so the config file is never left partially
+// written. The comments and the order of the keys are not preserved
+func writeConfigurationSettings(path string, settings map[string]interface{}) error {
+	v := viper.New()
+	if err := v.MergeConfigMap(settings); err != nil {
+		return fmt.Errorf("config: failed to load the %s config file settings: %w", path, err)
+	}
+
+	// NOTE: The temporary file keeps the extension, as it is used to select the format of the written file
+	ext := filepath.Ext(path)
+	tempPath := filepath.Join(filepath.Dir(path), fmt.Sprintf(".%s.tmp%s", strings.TrimSuffix(filepath.Base(path), ext), ext))
+
+	if err := v.WriteConfigAs(tempPath); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("config: failed to write the %s config file: %w", path, err)
+	}
+
+	// NOTE: The permissions of the config file are preserved, as the config file may contain secrets
+	if info, err := os.Stat(path); err == nil {
+		if err := os.Chmod(tempPath, info.Mode().Perm()); err != nil {
+			os.Remove(tempPath)
+			return fmt.Errorf("config: failed to set the %s config file permissions: %w", path, err)
+		}
+	}
+
+	if err := os.Rename(tempPath, path); err != nil {
+		os.Remove(tempPath)
+		return fmt.Errorf("config: failed to replace the %s config file: %w", path, err)
+	}
+
+	return nil
+}
+
+func decodeConfigurationFile(f *configurationFile) (*ApiKitServerConfiguration, []string, error) {
+	v := viper.New()
+	if err := v.MergeConfigMap(f.settings); err != nil {
+		return nil, nil, fmt.Errorf("config: failed to load the %s config file settings: %w", f.path, err)
+	}
+
+	// NOTE: Strict decoding is used in order to report unknown and misspelled keys
 	configuration := new(apiKitServerConfiguration)
-	if err := viper.Unmarshal(&configuration); err != nil {
-		return nil, fmt.Errorf("config: failed to unmarshal the config file content: %w", err)
+	if err := v.Unmarshal(&configuration, func(dc *mapstructure.DecoderConfig) { dc.ErrorUnused = true }); err != nil {
+		return nil, nil, fmt.Errorf("config: failed to unmarshal the %s config file content: %w", f.path, err)
+	}
+
+	// NOTE: Included config files are not required to specify the general section
+	if configuration.ApiKit == nil {
+		configuration.ApiKit = new(apiKitConfiguration)
+	}
+
+	markSourceValueDefaults(configuration, f.settings)
+
+	config, err := buildConfiguration(configuration)
+	if err != nil {
+		var errs ValidationErrors
+		if errors.As(err, &errs) {
+			for _, e := range errs {
+				e.File = f.path
+			}
+		}
+
+		return nil, nil, fmt.Errorf("config: failed to build the configuration from the %s file: %w", f.path, err)
+	}
+
+	config.Secrets = f.secrets
+	config.Files = []string{f.path}
+	return config, configuration.Include, nil
+}
+
+// Mark the source values that specify a default value. A null default is decoded the same as an absent default, so the
+// presence of the default key is looked up in the config file settings
+func markSourceValueDefaults(c *apiKitServerConfiguration, settings map[string]interface{}) {
+	general, ok := settings["general"].(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	sourcesSettings := settingsMapSlice(general, "sources")
+	for sourceIndex, source := range c.ApiKit.Sources {
+		if sourceIndex >= len(sourcesSettings) || source == nil {
+			return
+		}
+
+		valuesSettings := settingsMapSlice(sourcesSettings[sourceIndex], "values")
+		for valueIndex, value := range source.Values {
+			if valueIndex >= len(valuesSettings) || value == nil {
+				break
+			}
+
+			_, value.hasDefault = valuesSettings[valueIndex]["default"]
+		}
+	}
+}
+
+func settingsStringSlice(settings map[string]interface{}, key string) []string {
+	values, ok := settings[key].([]interface{})
+	if !ok {
+		return nil
 	}
 
-	if config, err := buildConfiguration(configuration); err != nil {
-		return nil, fmt.Errorf("config: failed to build the configuration from file: %w", err)
-	} else {
-		return config, nil
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, fmt.Sprint(value))
 	}
+
+	return result
 }
 
 func buildConfiguration(c *apiKitServerConfiguration) (*ApiKitServerConfiguration, error) {
@@ -100,6 +543,7 @@ func buildConfiguration(c *apiKitServerConfiguration) (*ApiKitServerConfiguratio
 		ApiKit: &ApiKitConfiguration{
 			Sources:   make([]*SourceConfiguration, 0, len(c.ApiKit.Sources)),
 			Endpoints: make([]*EndpointConfiguration, 0, len(c.ApiKit.Endpoints)),
+			Mappings:  make([]*MappingConfiguration, 0, len(c.ApiKit.Mappings)),
 		},
 		Endpoints:   make([]*ApiKitServerEndpointConfiguration, 0, len(c.Endpoints)),
 		ApiKeys:     make([]*ApiKitServerKeyConfiguration, 0, len(c.ApiKeys)),
@@ -107,6 +551,16 @@ func buildConfiguration(c *apiKitServerConfiguration) (*ApiKitServerConfiguratio
 		Host:        c.Host,
 	}
 
+	// NOTE: The invalid option values are collected in order to report all of them at once
+	var errs ValidationErrors
+
+	if c.Admin != nil {
+		config.Admin = &ApiKitServerAdminConfiguration{
+			Enabled:            c.Admin.Enabled,
+			RequiredApiKeyPool: c.Admin.RequiredApiKeyPool,
+		}
+	}
+
 	for _, apiKey := range c.ApiKeys {
 		config.ApiKeys = append(config.ApiKeys, &ApiKitServerKeyConfiguration{
 			Name:   apiKey.Name,
@@ -122,55 +576,276 @@ func buildConfiguration(c *apiKitServerConfiguration) (*ApiKitServerConfiguratio
 		})
 	}
 
-	for _, endpoint := range c.ApiKit.Endpoints {
+	for _, mapping := range c.ApiKit.Mappings {
+		entries := make([]*MappingEntryConfiguration, 0, len(mapping.Entries))
+		for _, entry := range mapping.Entries {
+			entries = append(entries, &MappingEntryConfiguration{
+				Match: entry.Match,
+				Regex: entry.Regex,
+				Value: entry.Value,
+			})
+		}
+
+		config.ApiKit.Mappings = append(config.ApiKit.Mappings, &MappingConfiguration{
+			Name:            mapping.Name,
+			Entries:         entries,
+			Default:         mapping.Default,
+			FailOnUnmatched: mapping.FailOnUnmatched,
+			CaseInsensitive: mapping.CaseInsensitive,
+		})
+	}
+
+	for endpointIndex, endpoint := range c.ApiKit.Endpoints {
 		endpointValues := make([]*EndpointValueConfiguration, 0, len(endpoint.Values))
 		for _, value := range endpoint.Values {
 			endpointValues = append(endpointValues, &EndpointValueConfiguration{
 				Name:            value.Name,
 				SourceName:      value.SourceName,
 				SourceValueName: value.SourceValueName,
+				Expression:      value.Expression,
+				Hidden:          value.Hidden,
 			})
 		}
 
+		var missingValues MissingValuesStrategy
+		switch strings.ToLower(endpoint.MissingValues) {
+		case "", "null":
+			missingValues = NullMissingValues
+		case "skip":
+			missingValues = SkipMissingValues
+		default:
+			errs.add(keyPath(indexPath("general", "endpoints", endpointIndex), "missing-values"), "invalid missing values strategy")
+		}
+
 		config.ApiKit.Endpoints = append(config.ApiKit.Endpoints, &EndpointConfiguration{
-			Name:   endpoint.Name,
-			Values: endpointValues,
+			Name:          endpoint.Name,
+			Values:        endpointValues,
+			MissingValues: missingValues,
+			Metadata:      endpoint.Metadata,
+			NestedValues:  endpoint.NestedValues,
 		})
 	}
 
-	for _, source := range c.ApiKit.Sources {
+	for sourceIndex, source := range c.ApiKit.Sources {
 		sourceValues := make([]*SourceValueConfiguration, 0, len(source.Values))
-		for _, value := range source.Values {
+		for valueIndex, value := range source.Values {
+			valuePath := indexPath(indexPath("general", "sources", sourceIndex), "values", valueIndex)
+
+			valueType := value.Type
+			if valueType == nil {
+				valueType = new(sourceValueTypeConfiguration)
+			}
+
 			var extractionStrategy ExtractionStrategy
 			switch strings.ToLower(value.ExtractionStrategy) {
 			case "first":
 				extractionStrategy = First
 			case "single":
 				extractionStrategy = Single
+			case "all":
+				extractionStrategy = All
 			default:
-				return nil, fmt.Errorf("config: invalid extraction strategy for %s in %s", value.Name, source.Name)
+				errs.add(keyPath(valuePath, "extraction-strategy"), "invalid extraction strategy")
 			}
 
-			var variableType VariableType
-			switch strings.ToLower(value.Type) {
-			case "string":
-				variableType = String
-			case "int":
-				variableType = Int
-			case "float":
-				variableType = Float
+			var extractionMode ExtractionMode
+			switch strings.ToLower(value.ExtractionMode) {
+			case "", "text":
+				extractionMode = Text
+			case "inner-html":
+				extractionMode = InnerHtml
+			case "outer-html":
+				extractionMode = OuterHtml
+			case "own-text":
+				extractionMode = OwnText
 			default:
-				return nil, fmt.Errorf("config: invalid variable type for %s in %s", value.Name, source.Name)
+				errs.add(keyPath(valuePath, "extraction-mode"), "invalid extraction mode")
+			}
+
+			var unitNormalization UnitNormalization
+			switch strings.ToLower(valueType.UnitNormalization) {
+			case "", "none":
+				unitNormalization = NoUnitNormalization
+			case "bytes":
+				unitNormalization = BytesUnitNormalization
+			case "bytes-binary":
+				unitNormalization = BinaryBytesUnitNormalization
+			default:
+				errs.add(keyPath(valuePath, "type.unit-normalization"), "invalid number unit normalization")
+			}
+
+			transforms := make([]*SourceValueTransformConfiguration, 0, len(value.Transforms))
+			for transformIndex, transform := range value.Transforms {
+				var transformType TransformType
+				switch strings.ToLower(transform.Type) {
+				case "trim":
+					transformType = TrimTransform
+				case "replace":
+					transformType = ReplaceTransform
+				case "regex-extract":
+					transformType = RegexExtractTransform
+				case "regex-replace":
+					transformType = RegexReplaceTransform
+				case "lower":
+					transformType = LowerTransform
+				case "upper":
+					transformType = UpperTransform
+				case "split":
+					transformType = SplitTransform
+				case "unicode-normalize":
+					transformType = UnicodeNormalizeTransform
+				case "strip-prefix":
+					transformType = StripPrefixTransform
+				case "strip-suffix":
+					transformType = StripSuffixTransform
+				case "multiply":
+					transformType = MultiplyTransform
+				case "offset":
+					transformType = OffsetTransform
+				default:
+					errs.add(keyPath(indexPath(valuePath, "transforms", transformIndex), "type"), "invalid transform type")
+				}
+
+				transforms = append(transforms, &SourceValueTransformConfiguration{
+					Type:        transformType,
+					Old:         transform.Old,
+					New:         transform.New,
+					Regex:       transform.Regex,
+					RegexIndex:  transform.RegexIndex,
+					Replacement: transform.Replacement,
+					Separator:   transform.Separator,
+					Index:       transform.Index,
+					Value:       transform.Value,
+					Operand:     transform.Operand,
+				})
+			}
+
+			regexGroups := make([]*SourceValueRegexGroupConfiguration, 0, len(value.ExtractionRegexGroups))
+			for groupIndex, group := range value.ExtractionRegexGroups {
+				groupTypeName := group.Type
+				if len(groupTypeName) == 0 {
+					groupTypeName = "string"
+				}
+
+				groupType, ok := parseVariableType(groupTypeName)
+				if !ok {
+					errs.add(keyPath(indexPath(valuePath, "extraction-regex-groups", groupIndex), "type"), "invalid regex group variable type")
+				}
+
+				regexGroups = append(regexGroups, &SourceValueRegexGroupConfiguration{
+					Name: group.Name,
+					Type: groupType,
+				})
+			}
+
+			var constraints *SourceValueConstraintsConfiguration = nil
+			if value.Constraints != nil {
+				constraints = &SourceValueConstraintsConfiguration{
+					Min:             value.Constraints.Min,
+					Max:             value.Constraints.Max,
+					AllowedValues:   value.Constraints.AllowedValues,
+					Pattern:         value.Constraints.Pattern,
+					MinLength:       value.Constraints.MinLength,
+					MaxLength:       value.Constraints.MaxLength,
+					Monotonic:       value.Constraints.Monotonic,
+					ResetOnDecrease: value.Constraints.ResetOnDecrease,
+				}
+			}
+
+			var valueKind ValueKind
+			switch strings.ToLower(value.Kind) {
+			case "", "scalar":
+				valueKind = Scalar
+			case "table":
+				valueKind = Table
+			default:
+				errs.add(keyPath(valuePath, "kind"), "invalid value kind")
+			}
+
+			variableTypeName := valueType.Name
+			if valueKind == Table && len(variableTypeName) == 0 {
+				variableTypeName = "string"
+			}
+
+			variableType, ok := parseVariableType(variableTypeName)
+			if !ok {
+				errs.add(keyPath(valuePath, "type.name"), "invalid variable type")
+			}
+
+			var table *SourceValueTableConfiguration = nil
+			if value.Table != nil {
+				columns := make([]*SourceValueTableColumnConfiguration, 0, len(value.Table.Columns))
+				for columnIndex, column := range value.Table.Columns {
+					columnTypeName := column.Type
+					if len(columnTypeName) == 0 {
+						columnTypeName = "string"
+					}
+
+					columnType, ok := parseVariableType(columnTypeName)
+					if !ok {
+						errs.add(keyPath(indexPath(keyPath(valuePath, "table"), "columns", columnIndex), "type"), "invalid table column variable type")
+					}
+
+					columns = append(columns, &SourceValueTableColumnConfiguration{
+						Name:  column.Name,
+						Index: column.Index,
+						Type:  columnType,
+					})
+				}
+
+				rowFilters := make([]*SourceValueTableRowFilterConfiguration, 0, len(value.Table.RowFilters))
+				for _, rowFilter := range value.Table.RowFilters {
+					rowFilters = append(rowFilters, &SourceValueTableRowFilterConfiguration{
+						Column:  rowFilter.Column,
+						Regex:   rowFilter.Regex,
+						Exclude: rowFilter.Exclude,
+					})
+				}
+
+				table = &SourceValueTableConfiguration{
+					HeaderRow:      value.Table.HeaderRow,
+					SkipHeaderRows: value.Table.SkipHeaderRows,
+					SkipFooterRows: value.Table.SkipFooterRows,
+					Columns:        columns,
+					RowFilters:     rowFilters,
+				}
 			}
 
 			sourceValues = append(sourceValues, &SourceValueConfiguration{
-				Name:                 value.Name,
-				Xpath:                value.Xpath,
-				ExtractionStrategy:   extractionStrategy,
-				ExtractionTrim:       value.ExtractionTrim,
-				ExtractionRegex:      value.ExtractionRegex,
-				ExtractionRegexIndex: value.ExtractionRegexIndex,
-				Type:                 variableType,
+				Name:                    value.Name,
+				Xpath:                   value.Xpath,
+				CssSelector:             value.CssSelector,
+				ExtractionStrategy:      extractionStrategy,
+				ExtractionMode:          extractionMode,
+				ExtractionAttribute:     value.ExtractionAttribute,
+				ExtractionStyleProperty: value.ExtractionStyleProperty,
+				ExtractionJoin:          value.ExtractionJoin,
+				ExtractionJoinSeparator: value.ExtractionJoinSeparator,
+				ExtractionMinCount:      value.ExtractionMinCount,
+				ExtractionMaxCount:      value.ExtractionMaxCount,
+				ExtractionTrim:          value.ExtractionTrim,
+				ExtractionRegex:         value.ExtractionRegex,
+				ExtractionRegexIndex:    value.ExtractionRegexIndex,
+				ExtractionRegexGroups:   regexGroups,
+				Type:                    variableType,
+				BoolTruthyValues:        valueType.TruthyValues,
+				BoolFalsyValues:         valueType.FalsyValues,
+				TimestampLayout:         valueType.Layout,
+				TimestampTimeZone:       valueType.TimeZone,
+				NumberDecimalSeparator:  valueType.DecimalSeparator,
+				NumberGroupingSeparator: valueType.GroupingSeparator,
+				NumberStripUnit:         valueType.StripUnit,
+				NumberAllowPrefixes:     valueType.AllowPrefixes,
+				NumberUnitNormalization: unitNormalization,
+				Transforms:              transforms,
+				Mapping:                 value.Mapping,
+				Required:                value.Required == nil || *value.Required,
+				Default:                 value.Default,
+				HasDefault:              value.hasDefault,
+				Constraints:             constraints,
+				Unit:                    value.Unit,
+				Kind:                    valueKind,
+				Table:                   table,
 			})
 		}
 
@@ -186,9 +861,38 @@ func buildConfiguration(c *apiKitServerConfiguration) (*ApiKitServerConfiguratio
 		})
 	}
 
-	if valid, msg := ValidateServer(config); !valid {
-		return nil, fmt.Errorf("config: validation failed due to %s", msg)
-	} else {
-		return config, nil
+	if len(errs) != 0 {
+		return nil, errs
+	}
+
+	return config, nil
+}
+
+func parseVariableType(value string) (VariableType, bool) {
+	switch strings.ToLower(value) {
+	case "string":
+		return String, true
+	case "int":
+		return Int, true
+	case "float":
+		return Float, true
+	case "int64":
+		return Int64, true
+	case "uint64":
+		return Uint64, true
+	case "bool":
+		return Bool, true
+	case "timestamp":
+		return Timestamp, true
+	case "duration":
+		return Duration, true
+	case "ip":
+		return Ip, true
+	case "cidr":
+		return Cidr, true
+	case "mac":
+		return Mac, true
+	default:
+		return 0, false
 	}
 }
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/Krzysztofz01/apikit/internal/utils"
+)
+
+// Resolve the include patterns relative to the directory of the including config file. A pattern is either a glob
+// pattern or a directory path (e.g. conf.d) from which all config files with a supported extension are included
+func resolveIncludePaths(path string, include []string) ([]string, error) {
+	baseDir := filepath.Dir(path)
+
+	paths := make([]string, 0)
+	for _, pattern := range include {
+		if len(pattern) == 0 {
+			return nil, fmt.Errorf("config: invalid empty include pattern")
+		}
+
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(baseDir, pattern)
+		}
+
+		if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+			entries, err := os.ReadDir(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("config: failed to read the %s include directory: %w", pattern, err)
+			}
+
+			for _, entry := range entries {
+				if !entry.IsDir() && isSupportedConfigFile(entry.Name()) {
+					paths = append(paths, filepath.Join(pattern, entry.Name()))
+				}
+			}
+
+			continue
+		}
+
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("config: invalid %s include pattern: %w", pattern, err)
+		}
+
+		for _, match := range matches {
+			if info, err := os.Stat(match); err == nil && !info.IsDir() {
+				paths = append(paths, match)
+			}
+		}
+	}
+
+	// NOTE: The files are merged in a deterministic order and a file matched by multiple patterns is included once
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	return slices.DeleteFunc(paths, func(includedPath string) bool {
+		return filepath.Clean(includedPath) == filepath.Clean(path)
+	}), nil
+}
+
+// Resolve the existing directories in which the config files matched by the include patterns can be created. For a glob
+// pattern the directory is only resolved if the directory part of the pattern is not a glob pattern itself
+func resolveIncludeDirectories(path string, include []string) []string {
+	baseDir := filepath.Dir(path)
+
+	dirs := make([]string, 0, len(include))
+	for _, pattern := range include {
+		if len(pattern) == 0 {
+			continue
+		}
+
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(baseDir, pattern)
+		}
+
+		if info, err := os.Stat(pattern); err != nil || !info.IsDir() {
+			pattern = filepath.Dir(pattern)
+		}
+
+		if strings.ContainsAny(pattern, "*?[") {
+			continue
+		}
+
+		if info, err := os.Stat(pattern); err == nil && info.IsDir() {
+			dirs = append(dirs, pattern)
+		}
+	}
+
+	slices.Sort(dirs)
+	return slices.Compact(dirs)
+}
+
+func isSupportedConfigFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".json", ".yaml", ".yml", ".toml":
+		return true
+	default:
+		return false
+	}
+}
+
+// Merge the sources, endpoints, mappings, server endpoints and api keys of the config files into a single configuration.
+// The server and admin api options are taken from the first config file. Duplicate names are reported together with the defining files
+func mergeConfigurations(configs []*ApiKitServerConfiguration) (*ApiKitServerConfiguration, error) {
+	merged := &ApiKitServerConfiguration{
+		ApiKit: &ApiKitConfiguration{
+			Sources:   make([]*SourceConfiguration, 0),
+			Endpoints: make([]*EndpointConfiguration, 0),
+			Mappings:  make([]*MappingConfiguration, 0),
+		},
+		Endpoints:   make([]*ApiKitServerEndpointConfiguration, 0),
+		ApiKeys:     make([]*ApiKitServerKeyConfiguration, 0),
+		VerboseMode: configs[0].VerboseMode,
+		Host:        configs[0].Host,
+		Admin:       configs[0].Admin,
+		Secrets:     make([]string, 0),
+		Files:       make([]string, 0, len(configs)),
+	}
+
+	sourceFiles := make(map[string]string)
+	endpointFiles := make(map[string]string)
+	mappingFiles := make(map[string]string)
+	serverEndpointFiles := make(map[string]string)
+	apiKeyFiles := make(map[string]string)
+	secrets := utils.NewEmptySet[string]()
+
+	for _, config := range configs {
+		path := config.Files[0]
+
+		for _, source := range config.ApiKit.Sources {
+			if err := addMergedName(sourceFiles, "source", source.Name, path); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, endpoint := range config.ApiKit.Endpoints {
+			if err := addMergedName(endpointFiles, "endpoint", endpoint.Name, path); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, mapping := range config.ApiKit.Mappings {
+			if err := addMergedName(mappingFiles, "mapping", mapping.Name, path); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, endpoint := range config.Endpoints {
+			if err := addMergedName(serverEndpointFiles, "server endpoint path", endpoint.Path, path); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, apiKey := range config.ApiKeys {
+			if err := addMergedName(apiKeyFiles, "api key", apiKey.Name, path); err != nil {
+				return nil, err
+			}
+		}
+
+		merged.ApiKit.Sources = append(merged.ApiKit.Sources, config.ApiKit.Sources...)
+		merged.ApiKit.Endpoints = append(merged.ApiKit.Endpoints, config.ApiKit.Endpoints...)
+		merged.ApiKit.Mappings = append(merged.ApiKit.Mappings, config.ApiKit.Mappings...)
+		merged.Endpoints = append(merged.Endpoints, config.Endpoints...)
+		merged.ApiKeys = append(merged.ApiKeys, config.ApiKeys...)
+		merged.Files = append(merged.Files, path)
+
+		for _, secret := range config.Secrets {
+			if secrets.Add(secret) {
+				merged.Secrets = append(merged.Secrets, secret)
+			}
+		}
+	}
+
+	return merged, nil
+}
+
+func addMergedName(files map[string]string, kind, name, path string) error {
+	// NOTE: Duplicates within a single config file are reported by the validation together with their config paths
+	if previousPath, ok := files[name]; ok && previousPath != path {
+		return fmt.Errorf("config: duplicate %s %s defined in the %s and %s config files", kind, name, previousPath, path)
+	}
+
+	files[name] = path
+	return nil
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/Krzysztofz01/apikit/internal/utils"
+)
+
+const secretFileReferencePrefix string = "file:"
+
+var envReferenceRegex = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// Resolve the environment variable and secret file references of all string values of the raw settings. Only the values
+// at the sensitive positions are added to the secrets set: the api key secrets, the http headers and the user info of the
+// urls. The values of other settings (e.g. ports or hosts) are not redacted, as they would garble all log messages
+func interpolateSettings(value interface{}, key string, sensitive bool, secrets utils.Set[string]) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		// NOTE: All values of the http headers are considered sensitive as they usually carry credentials
+		for childKey, child := range v {
+			if resolved, err := interpolateSettings(child, childKey, sensitive || key == "http-headers", secrets); err != nil {
+				return nil, fmt.Errorf("config: failed to resolve the %s setting: %w", childKey, err)
+			} else {
+				v[childKey] = resolved
+			}
+		}
+
+		return v, nil
+	case []interface{}:
+		for index, child := range v {
+			if resolved, err := interpolateSettings(child, key, sensitive, secrets); err != nil {
+				return nil, fmt.Errorf("config: failed to resolve the %d element: %w", index, err)
+			} else {
+				v[index] = resolved
+			}
+		}
+
+		return v, nil
+	case string:
+		sensitive = sensitive || key == "secret"
+
+		resolved, substitutions, err := resolveReferences(v, sensitive)
+		if err != nil {
+			return nil, err
+		}
+
+		if sensitive {
+			if len(resolved) != 0 {
+				secrets.Add(resolved)
+			}
+
+			for _, substitution := range substitutions {
+				if len(substitution) != 0 {
+					secrets.Add(substitution)
+				}
+			}
+		}
+
+		if key == "url" {
+			for _, userInfo := range urlUserInfoSecrets(resolved) {
+				secrets.Add(userInfo)
+			}
+		}
+
+		return resolved, nil
+	default:
+		return value, nil
+	}
+}
+
+// Resolve the "${ENV}" and "${ENV:-default}" environment variable references and, for the credential settings only, a
+// "file:" secret file reference. The environment variable values substituted for the references are returned alongside
+// the resolved value, while the default values are not, as they are literals of the config file
+func resolveReferences(value string, credential bool) (string, []string, error) {
+	if path, ok := strings.CutPrefix(value, secretFileReferencePrefix); ok && credential {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", nil, fmt.Errorf("config: failed to read the %s secret file: %w", path, err)
+		}
+
+		return strings.TrimRight(string(content), "\r\n"), nil, nil
+	}
+
+	var (
+		resolveErr    error
+		substitutions []string
+	)
+
+	resolved := envReferenceRegex.ReplaceAllStringFunc(value, func(reference string) string {
+		matches := envReferenceRegex.FindStringSubmatch(reference)
+
+		if envValue, ok := os.LookupEnv(matches[1]); ok {
+			substitutions = append(substitutions, envValue)
+			return envValue
+		}
+
+		if len(matches[2]) != 0 {
+			return matches[3]
+		}
+
+		if resolveErr == nil {
+			resolveErr = fmt.Errorf("config: referenced environment variable %s is not set", matches[1])
+		}
+
+		return ""
+	})
+
+	if resolveErr != nil {
+		return "", nil, resolveErr
+	}
+
+	return resolved, substitutions, nil
+}
+
+// Get the sensitive part of the url user info, which is the password or the user name if the password is not specified
+// (e.g. a token passed as the user name)
+func urlUserInfoSecrets(rawUrl string) []string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || parsedUrl.User == nil {
+		return nil
+	}
+
+	if password, ok := parsedUrl.User.Password(); ok {
+		if len(password) == 0 {
+			return nil
+		}
+
+		return []string{password, parsedUrl.User.String()}
+	}
+
+	if username := parsedUrl.User.Username(); len(username) != 0 {
+		return []string{username}
+	}
+
+	return nil
+}
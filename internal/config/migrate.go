@@ -0,0 +1,152 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Version of the config file layout. Config files without the version field are considered to use the first version
+const CurrentConfigVersion int = 2
+
+const legacyConfigVersion int = 1
+
+// Migration of the raw settings of a config file from the layout of the previous version into the layout of the given version
+type configMigration struct {
+	version int
+	migrate func(settings map[string]interface{}) []string
+}
+
+var configMigrations = []*configMigration{
+	{version: 2, migrate: migrateSourceValueTypeObjects},
+}
+
+// Result of the migration of a single config file to the current version layout
+type ConfigurationFileMigration struct {
+	Path        string
+	FromVersion int
+	ToVersion   int
+	Changes     []string
+}
+
+// Migrate the config file at the given path and the included config files to the current version layout. The migrated
+// files are only written when requested, the original files are preserved with the ".bak" extension
+func MigrateConfigurationFiles(path string, write bool) ([]*ConfigurationFileMigration, error) {
+	settings, err := readConfigurationSettings(path)
+	if err != nil {
+		return nil, err
+	}
+
+	includedPaths, err := resolveIncludePaths(path, settingsStringSlice(settings, "include"))
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to resolve the included config files of %s: %w", path, err)
+	}
+
+	migrations := make([]*ConfigurationFileMigration, 0, len(includedPaths)+1)
+	for _, filePath := range append([]string{path}, includedPaths...) {
+		if migration, err := migrateConfigurationFile(filePath, write); err != nil {
+			return nil, err
+		} else {
+			migrations = append(migrations, migration)
+		}
+	}
+
+	return migrations, nil
+}
+
+func migrateConfigurationFile(path string, write bool) (*ConfigurationFileMigration, error) {
+	// NOTE: The raw settings are migrated, so the environment variable and secret file references are preserved
+	settings, err := readConfigurationSettings(path)
+	if err != nil {
+		return nil, err
+	}
+
+	version, changes, err := migrateSettings(settings)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to migrate the %s config file: %w", path, err)
+	}
+
+	migration := &ConfigurationFileMigration{
+		Path:        path,
+		FromVersion: version,
+		ToVersion:   CurrentConfigVersion,
+		Changes:     changes,
+	}
+
+	if !write || version == CurrentConfigVersion {
+		return migration, nil
+	}
+
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to read the %s config file: %w", path, err)
+	}
+
+	if err := os.WriteFile(path+".bak", original, 0o600); err != nil {
+		return nil, fmt.Errorf("config: failed to backup the %s config file: %w", path, err)
+	}
+
+	if err := writeConfigurationSettings(path, settings); err != nil {
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+// Migrate the raw settings to the current version layout in place. The version of the settings before the migration is
+// returned together with the descriptions of the applied changes
+func migrateSettings(settings map[string]interface{}) (int, []string, error) {
+	version := legacyConfigVersion
+	if rawVersion, ok := settings["version"]; ok {
+		if parsedVersion, err := strconv.Atoi(fmt.Sprint(rawVersion)); err != nil {
+			return 0, nil, fmt.Errorf("config: invalid config version %v", rawVersion)
+		} else {
+			version = parsedVersion
+		}
+	}
+
+	if version < legacyConfigVersion || version > CurrentConfigVersion {
+		return 0, nil, fmt.Errorf("config: unsupported config version %d, the supported versions are %d to %d", version, legacyConfigVersion, CurrentConfigVersion)
+	}
+
+	changes := make([]string, 0)
+	for _, migration := range configMigrations {
+		if migration.version > version {
+			changes = append(changes, migration.migrate(settings)...)
+		}
+	}
+
+	settings["version"] = CurrentConfigVersion
+	return version, changes, nil
+}
+
+// Move the source value type names of the sources and source templates into the type objects
+func migrateSourceValueTypeObjects(settings map[string]interface{}) []string {
+	general, ok := settings["general"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	changes := make([]string, 0)
+	for _, sourcesKey := range []string{"sources", "source-templates"} {
+		for sourceIndex, source := range settingsMapSlice(general, sourcesKey) {
+			for valueIndex, value := range settingsMapSlice(source, "values") {
+				typeName, ok := value["type"]
+				if !ok {
+					continue
+				}
+
+				if _, ok := typeName.(map[string]interface{}); ok {
+					continue
+				}
+
+				value["type"] = map[string]interface{}{"name": typeName}
+
+				valuePath := indexPath(indexPath("general", sourcesKey, sourceIndex), "values", valueIndex)
+				changes = append(changes, fmt.Sprintf("%s: moved the type name into the type object", keyPath(valuePath, "type")))
+			}
+		}
+	}
+
+	return changes
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateSettingsShouldMoveSourceValueTypeIntoObject(t *testing.T) {
+	settings := map[string]interface{}{
+		"general": map[string]interface{}{
+			"sources": []interface{}{
+				map[string]interface{}{"name": "router", "values": []interface{}{
+					map[string]interface{}{"name": "uptime", "type": "float"},
+					map[string]interface{}{"name": "model", "type": map[string]interface{}{"name": "string"}},
+				}},
+			},
+		},
+	}
+
+	version, changes, err := migrateSettings(settings)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, version)
+	assert.Equal(t, []string{"general.sources[0].values[0].type: moved the type name into the type object"}, changes)
+	assert.Equal(t, CurrentConfigVersion, settings["version"])
+
+	values := settings["general"].(map[string]interface{})["sources"].([]interface{})[0].(map[string]interface{})["values"].([]interface{})
+	assert.Equal(t, map[string]interface{}{"name": "uptime", "type": map[string]interface{}{"name": "float"}}, values[0])
+	assert.Equal(t, map[string]interface{}{"name": "model", "type": map[string]interface{}{"name": "string"}}, values[1])
+}
+
+func TestMigrateSettingsShouldFailForUnsupportedVersion(t *testing.T) {
+	cases := []interface{}{0, CurrentConfigVersion + 1, "latest"}
+
+	for _, c := range cases {
+		_, _, err := migrateSettings(map[string]interface{}{"version": c})
+
+		assert.NotNil(t, err)
+	}
+}
+
+func TestMigrateConfigurationFilesShouldRewriteLegacyFile(t *testing.T) {
+	content := `{"host": "${APIKIT_TEST_MIGRATE_HOST:-localhost:8080}", "general": {"sources": [{"name": "router", "values": [{"name": "uptime", "xpath": "//td", "extraction-strategy": "first", "type": "int"}]}]}}`
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+
+	legacyConfig, err := LoadServerConfigurationFromFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, "version", legacyConfig.Warnings[0].Path)
+
+	migrations, err := MigrateConfigurationFiles(path, true)
+	assert.Nil(t, err)
+	assert.Len(t, migrations, 1)
+	assert.Equal(t, 1, migrations[0].FromVersion)
+
+	backup, err := os.ReadFile(path + ".bak")
+	assert.Nil(t, err)
+	assert.Equal(t, content, string(backup))
+
+	migrated, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(migrated), "${APIKIT_TEST_MIGRATE_HOST:-localhost:8080}")
+
+	config, err := LoadServerConfigurationFromFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, Int, config.ApiKit.Sources[0].Values[0].Type)
+	assert.NotContains(t, config.Warnings.Error(), "deprecated")
+}
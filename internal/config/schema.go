@@ -0,0 +1,112 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	jsonSchemaDraft string = "https://json-schema.org/draft/2020-12/schema"
+	jsonSchemaTitle string = "apikit configuration"
+)
+
+var variableTypeNames = []string{"string", "int", "float", "int64", "uint64", "bool", "timestamp", "duration", "ip", "cidr", "mac"}
+
+// The allowed values of the string enum options, keyed by the config struct type name and the option key
+var jsonSchemaEnums = map[string][]string{
+	"endpointConfiguration.missing-values":            {"null", "skip"},
+	"sourceValueConfiguration.extraction-strategy":    {"first", "single", "all"},
+	"sourceValueConfiguration.extraction-mode":        {"text", "inner-html", "outer-html", "own-text"},
+	"sourceValueTypeConfiguration.name":               variableTypeNames,
+	"sourceValueTypeConfiguration.unit-normalization": {"none", "bytes", "bytes-binary"},
+	"sourceValueConfiguration.kind":                   {"scalar", "table"},
+	"sourceValueRegexGroupConfiguration.type":         variableTypeNames,
+	"sourceValueTableColumnConfiguration.type":        variableTypeNames,
+	"sourceValueTransformConfiguration.type": {
+		"trim", "replace", "regex-extract", "regex-replace", "lower", "upper", "split",
+		"unicode-normalize", "strip-prefix", "strip-suffix", "multiply", "offset",
+	},
+}
+
+// Generate the JSON Schema of the config file based on the mapstructure tags of the config file structures. The schema
+// is not aware of the cross-field rules, which are still enforced by the configuration validation
+func GenerateJsonSchema() ([]byte, error) {
+	schema, err := jsonSchemaOf(reflect.TypeOf(apiKitServerConfiguration{}), "")
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to generate the json schema: %w", err)
+	}
+
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = jsonSchemaTitle
+
+	if content, err := json.MarshalIndent(schema, "", "  "); err != nil {
+		return nil, fmt.Errorf("config: failed to marshal the json schema: %w", err)
+	} else {
+		return content, nil
+	}
+}
+
+func jsonSchemaOf(t reflect.Type, enumKey string) (map[string]interface{}, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]interface{}, t.NumField())
+		for index := 0; index < t.NumField(); index += 1 {
+			field := t.Field(index)
+
+			key, ok := field.Tag.Lookup("mapstructure")
+			if !ok {
+				continue
+			}
+
+			if property, err := jsonSchemaOf(field.Type, fmt.Sprintf("%s.%s", t.Name(), key)); err != nil {
+				return nil, err
+			} else {
+				properties[key] = property
+			}
+		}
+
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}, nil
+	case reflect.Slice:
+		if items, err := jsonSchemaOf(t.Elem(), enumKey); err != nil {
+			return nil, err
+		} else {
+			return map[string]interface{}{"type": "array", "items": items}, nil
+		}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("config: unsupported %s map key type", t.Key().Kind())
+		}
+
+		if values, err := jsonSchemaOf(t.Elem(), enumKey); err != nil {
+			return nil, err
+		} else {
+			return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+		}
+	case reflect.String:
+		if enum, ok := jsonSchemaEnums[enumKey]; ok {
+			return map[string]interface{}{"type": "string", "enum": enum}, nil
+		}
+
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}, nil
+	case reflect.Interface:
+		return map[string]interface{}{}, nil
+	default:
+		return nil, fmt.Errorf("config: unsupported %s field type", strings.ToLower(t.Kind().String()))
+	}
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateJsonSchemaShouldMatchPublishedSchema(t *testing.T) {
+	schema, err := GenerateJsonSchema()
+	assert.Nil(t, err)
+
+	published, err := os.ReadFile("../../config.schema.json")
+	assert.Nil(t, err)
+
+	assert.JSONEq(t, string(published), string(schema))
+}
+
+func TestGenerateJsonSchemaShouldDescribeConfigKeys(t *testing.T) {
+	content, err := GenerateJsonSchema()
+	assert.Nil(t, err)
+
+	var schema map[string]interface{}
+	assert.Nil(t, json.Unmarshal(content, &schema))
+
+	general := schema["properties"].(map[string]interface{})["general"].(map[string]interface{})
+	sources := general["properties"].(map[string]interface{})["sources"].(map[string]interface{})
+	source := sources["items"].(map[string]interface{})
+
+	assert.Equal(t, false, source["additionalProperties"])
+	assert.Contains(t, source["properties"], "caching-life-time-seconds")
+	assert.NotContains(t, source["properties"], "caching-life-time-secs")
+}
@@ -2,12 +2,31 @@ package config
 
 import (
 	"net/url"
+	"reflect"
+	"strings"
 
 	"github.com/Krzysztofz01/apikit/internal/utils"
 )
 
+// Path prefix of the routes of the runtime configuration management api
+const AdminApiPathPrefix string = "/admin"
+
 func ValidateServer(c *ApiKitServerConfiguration) (bool, string) {
-	return c.isValid()
+	if errs := ValidateServerErrors(c); len(errs) != 0 {
+		return false, errs.Error()
+	}
+
+	return true, ""
+}
+
+// Validate the server configuration collecting all problems addressed by their config paths
+func ValidateServerErrors(c *ApiKitServerConfiguration) ValidationErrors {
+	return c.validate()
+}
+
+// Find the problems that do not prevent the configuration from being served, such as unused sources, source values and api keys
+func ValidateServerWarnings(c *ApiKitServerConfiguration) ValidationErrors {
+	return c.unusedItems()
 }
 
 type ApiKitServerConfiguration struct {
@@ -16,52 +35,171 @@ type ApiKitServerConfiguration struct {
 	ApiKeys     []*ApiKitServerKeyConfiguration
 	VerboseMode bool
 	Host        string
+	Secrets     []string
+	Files       []string
+	IncludeDirs []string
+	Warnings    ValidationErrors
+	Admin       *ApiKitServerAdminConfiguration
 }
 
-func (c *ApiKitServerConfiguration) isValid() (bool, string) {
-	// NOTE: Inner apikit config values validation
-	if valid, msg := c.ApiKit.isValid(); !valid {
-		return false, msg
+// Check if the configurations serve the same content. The secrets, files and warnings are derived from the served
+// content, so they are not compared
+func (c *ApiKitServerConfiguration) Equal(other *ApiKitServerConfiguration) bool {
+	if c == nil || other == nil {
+		return c == other
 	}
 
+	return c.VerboseMode == other.VerboseMode &&
+		c.Host == other.Host &&
+		reflect.DeepEqual(c.ApiKit, other.ApiKit) &&
+		reflect.DeepEqual(c.Endpoints, other.Endpoints) &&
+		reflect.DeepEqual(c.ApiKeys, other.ApiKeys) &&
+		reflect.DeepEqual(c.Admin, other.Admin)
+}
+
+// Check if the runtime configuration management api is enabled
+func (c *ApiKitServerConfiguration) IsAdminEnabled() bool {
+	return c.Admin != nil && c.Admin.Enabled
+}
+
+func (c *ApiKitServerConfiguration) validate() ValidationErrors {
+	// NOTE: Inner apikit config values validation
+	errs := c.ApiKit.validate("general")
+
 	if len(c.Host) == 0 {
-		return false, "invalid server host"
+		errs.add("host", "invalid server host")
 	}
 
 	serverKeyName := utils.NewEmptySet[string]()
 	serverKeySecret := utils.NewEmptySet[string]()
 
 	// NOTE: Server api keys names and secrets unique validation
-	for _, serverKey := range c.ApiKeys {
+	for index, serverKey := range c.ApiKeys {
+		serverKeyPath := indexPath("", "api-keys", index)
+
 		// NOTE: Inner api key config validation
-		if valid, msg := serverKey.isValid(); !valid {
-			return false, msg
-		}
+		errs = append(errs, serverKey.validate(serverKeyPath)...)
 
 		if !serverKeyName.Add(serverKey.Name) {
-			return false, "duplicate api key name found"
+			errs.add(keyPath(serverKeyPath, "name"), "duplicate api key name found")
 		}
 
 		if !serverKeySecret.Add(serverKey.Secret) {
-			return false, "duplicate api key secret found"
+			errs.add(keyPath(serverKeyPath, "secret"), "duplicate api key secret found")
 		}
 	}
 
-	for _, endpoint := range c.Endpoints {
+	endpointNames := utils.NewEmptySet[string]()
+	for _, endpoint := range c.ApiKit.Endpoints {
+		endpointNames.Add(endpoint.Name)
+	}
+
+	endpointPaths := utils.NewEmptySet[string]()
+	for endpointIndex, endpoint := range c.Endpoints {
+		endpointPath := indexPath("", "endpoints", endpointIndex)
+
 		// NOTE: Inner server endpoint config values validation
-		if valid, msg := endpoint.isValid(); !valid {
-			return false, msg
+		errs = append(errs, endpoint.validate(endpointPath)...)
+
+		// NOTE: Server endpoint name existance check
+		if len(endpoint.EndpointName) != 0 && !endpointNames.Contains(endpoint.EndpointName) {
+			errs.add(keyPath(endpointPath, "name"), "server endpoint references non existing endpoint")
+		}
+
+		// NOTE: Server endpoint paths unique validation
+		if !endpointPaths.Add(endpoint.Path) {
+			errs.add(keyPath(endpointPath, "path"), "duplicate server endpoint path found")
+		}
+
+		// NOTE: The admin api routes take precedence over the server endpoints
+		if c.IsAdminEnabled() && (endpoint.Path == AdminApiPathPrefix || strings.HasPrefix(endpoint.Path, AdminApiPathPrefix+"/")) {
+			errs.add(keyPath(endpointPath, "path"), "server endpoint path conflicts with the admin api")
 		}
 
 		// NOTE: Server endpoints api key pool name existance check
-		for _, apiKey := range endpoint.RequiredApiKeyPool {
+		for apiKeyIndex, apiKey := range endpoint.RequiredApiKeyPool {
 			if !serverKeyName.Contains(apiKey) {
-				return false, "endpoint referencing non existing api key"
+				errs.add(indexPath(endpointPath, "required-api-key-name-pool", apiKeyIndex), "endpoint referencing non existing api key")
 			}
 		}
 	}
 
-	return true, ""
+	if c.IsAdminEnabled() {
+		// NOTE: The admin api is required to be authenticated
+		if len(c.Admin.RequiredApiKeyPool) == 0 {
+			errs.add("admin.required-api-key-name-pool", "admin api requires at least one api key")
+		}
+
+		for index, apiKey := range c.Admin.RequiredApiKeyPool {
+			if !serverKeyName.Contains(apiKey) {
+				errs.add(indexPath("admin", "required-api-key-name-pool", index), "admin api referencing non existing api key")
+			}
+		}
+	}
+
+	return errs
+}
+
+func (c *ApiKitServerConfiguration) unusedItems() ValidationErrors {
+	var warnings ValidationErrors
+
+	usedSourceValues := make(map[string]utils.Set[string], len(c.ApiKit.Sources))
+	for _, endpoint := range c.ApiKit.Endpoints {
+		for _, value := range endpoint.Values {
+			if value.IsComputed() {
+				continue
+			}
+
+			if _, ok := usedSourceValues[value.SourceName]; !ok {
+				usedSourceValues[value.SourceName] = utils.NewEmptySet[string]()
+			}
+
+			usedSourceValues[value.SourceName].Add(value.SourceValueName)
+		}
+	}
+
+	for sourceIndex, source := range c.ApiKit.Sources {
+		sourcePath := indexPath("general", "sources", sourceIndex)
+
+		usedValues, ok := usedSourceValues[source.Name]
+		if !ok {
+			warnings.add(sourcePath, "source is not used by any endpoint")
+			continue
+		}
+
+		for valueIndex, value := range source.Values {
+			// NOTE: A value with regex groups is used when any of the values emitted by the groups is used
+			used := usedValues.Contains(value.Name)
+			for _, group := range value.ExtractionRegexGroups {
+				used = used || usedValues.Contains(group.Name)
+			}
+
+			if !used {
+				warnings.add(indexPath(sourcePath, "values", valueIndex), "source value is not used by any endpoint")
+			}
+		}
+	}
+
+	usedApiKeys := utils.NewEmptySet[string]()
+	for _, endpoint := range c.Endpoints {
+		for _, apiKey := range endpoint.RequiredApiKeyPool {
+			usedApiKeys.Add(apiKey)
+		}
+	}
+
+	if c.IsAdminEnabled() {
+		for _, apiKey := range c.Admin.RequiredApiKeyPool {
+			usedApiKeys.Add(apiKey)
+		}
+	}
+
+	for index, apiKey := range c.ApiKeys {
+		if !usedApiKeys.Contains(apiKey.Name) {
+			warnings.add(indexPath("", "api-keys", index), "api key is not required by any server endpoint or the admin api")
+		}
+	}
+
+	return warnings
 }
 
 type ApiKitServerKeyConfiguration struct {
@@ -69,16 +207,17 @@ type ApiKitServerKeyConfiguration struct {
 	Secret string
 }
 
-func (c *ApiKitServerKeyConfiguration) isValid() (bool, string) {
+func (c *ApiKitServerKeyConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
 	if len(c.Name) == 0 {
-		return false, "invalid server key name"
+		errs.add(keyPath(path, "name"), "invalid server key name")
 	}
 
-	if len(c.Name) == 0 {
-		return false, "invalid server key secret"
+	if len(c.Secret) == 0 {
+		errs.add(keyPath(path, "secret"), "invalid server key secret")
 	}
 
-	return true, ""
+	return errs
 }
 
 type ApiKitServerEndpointConfiguration struct {
@@ -87,18 +226,24 @@ type ApiKitServerEndpointConfiguration struct {
 	RequiredApiKeyPool []string
 }
 
-func (c *ApiKitServerEndpointConfiguration) isValid() (bool, string) {
+func (c *ApiKitServerEndpointConfiguration) validate(path string) ValidationErrors {
+	var errs ValidationErrors
 	if len(c.EndpointName) == 0 {
-		return false, "invalid endpoint name"
+		errs.add(keyPath(path, "name"), "invalid endpoint name")
 	}
 
 	if parsedPath, err := url.Parse(c.Path); err != nil || parsedPath.Host != "" || parsedPath.Scheme != "" {
-		return false, "invalid path format"
+		errs.add(keyPath(path, "path"), "invalid path format")
 	}
 
 	if c.RequiredApiKeyPool == nil {
-		return false, "uninitialized required api key pool collection"
+		errs.add(keyPath(path, "required-api-key-name-pool"), "uninitialized required api key pool collection")
 	}
 
-	return true, ""
+	return errs
+}
+
+type ApiKitServerAdminConfiguration struct {
+	Enabled            bool
+	RequiredApiKeyPool []string
 }
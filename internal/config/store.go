@@ -0,0 +1,391 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kind of the config items that can be managed at runtime
+type ConfigurationItemKind int
+
+const (
+	SourceItem ConfigurationItemKind = iota
+	SourceValueItem
+	EndpointItem
+	ApiKeyItem
+)
+
+var (
+	ErrConfigurationItemNotFound  = errors.New("config: configuration item not found")
+	ErrConfigurationItemExists    = errors.New("config: configuration item already exists")
+	ErrInvalidConfigurationChange = errors.New("config: invalid configuration change")
+)
+
+const redactedSettingValue string = "[REDACTED]"
+
+// Runtime management of the sources, source values, endpoints and api keys of the config file and the included config
+// files. The items are represented with the raw settings of the config file layout, where the parent name is the source
+// name of the source values. A change is validated against the whole configuration and returned together with a
+// function writing the changed config file, so the change can be applied before it is persisted
+type ConfigurationStore interface {
+	List(kind ConfigurationItemKind, parentName string) ([]map[string]interface{}, error)
+	Get(kind ConfigurationItemKind, parentName, name string) (map[string]interface{}, error)
+	Create(kind ConfigurationItemKind, parentName string, item map[string]interface{}) (*ApiKitServerConfiguration, func() error, error)
+	Update(kind ConfigurationItemKind, parentName, name string, item map[string]interface{}) (*ApiKitServerConfiguration, func() error, error)
+	Delete(kind ConfigurationItemKind, parentName, name string) (*ApiKitServerConfiguration, func() error, error)
+}
+
+type configurationStore struct {
+	path string
+}
+
+func CreateConfigurationStore(path string) (ConfigurationStore, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("config: invalid empty config file path provided")
+	}
+
+	return &configurationStore{
+		path: path,
+	}, nil
+}
+
+// NOTE: The config files are read on every operation, so the changes made to the files by hand are never overwritten
+func (s *configurationStore) List(kind ConfigurationItemKind, parentName string) ([]map[string]interface{}, error) {
+	files, err := readConfigurationFiles(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]map[string]interface{}, 0)
+	parentFound := false
+	for _, file := range files {
+		container, key, ok := findItemsContainer(file.raw, kind, parentName, false)
+		if !ok {
+			continue
+		}
+
+		parentFound = true
+		for _, item := range settingsMapSlice(container, key) {
+			items = append(items, redactItem(kind, item))
+		}
+	}
+
+	if kind == SourceValueItem && !parentFound {
+		return nil, fmt.Errorf("%w: source %s", ErrConfigurationItemNotFound, parentName)
+	}
+
+	return items, nil
+}
+
+func (s *configurationStore) Get(kind ConfigurationItemKind, parentName, name string) (map[string]interface{}, error) {
+	files, err := readConfigurationFiles(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := findItem(files, kind, parentName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if location.index == -1 {
+		return nil, fmt.Errorf("%w: %s", ErrConfigurationItemNotFound, name)
+	}
+
+	container, key, _ := findItemsContainer(files[location.fileIndex].raw, kind, parentName, false)
+	return redactItem(kind, settingsMapSlice(container, key)[location.index]), nil
+}
+
+func (s *configurationStore) Create(kind ConfigurationItemKind, parentName string, item map[string]interface{}) (*ApiKitServerConfiguration, func() error, error) {
+	item, name, err := normalizeItem(item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.change(kind, parentName, name, func(files []*configurationFile, location *itemLocation) (int, func([]interface{}) []interface{}, error) {
+		if location.index != -1 {
+			return 0, nil, fmt.Errorf("%w: %s", ErrConfigurationItemExists, name)
+		}
+
+		if err := restoreRedactedSettings(kind, item, nil); err != nil {
+			return 0, nil, err
+		}
+
+		return location.fileIndex, func(items []interface{}) []interface{} {
+			return append(items, item)
+		}, nil
+	})
+}
+
+func (s *configurationStore) Update(kind ConfigurationItemKind, parentName, name string, item map[string]interface{}) (*ApiKitServerConfiguration, func() error, error) {
+	item, _, err := normalizeItem(item)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.change(kind, parentName, name, func(files []*configurationFile, location *itemLocation) (int, func([]interface{}) []interface{}, error) {
+		if location.index == -1 {
+			return 0, nil, fmt.Errorf("%w: %s", ErrConfigurationItemNotFound, name)
+		}
+
+		container, key, _ := findItemsContainer(files[location.fileIndex].raw, kind, parentName, false)
+		if err := restoreRedactedSettings(kind, item, settingsMapSlice(container, key)[location.index]); err != nil {
+			return 0, nil, err
+		}
+
+		return location.fileIndex, func(items []interface{}) []interface{} {
+			items[location.index] = item
+			return items
+		}, nil
+	})
+}
+
+func (s *configurationStore) Delete(kind ConfigurationItemKind, parentName, name string) (*ApiKitServerConfiguration, func() error, error) {
+	return s.change(kind, parentName, name, func(files []*configurationFile, location *itemLocation) (int, func([]interface{}) []interface{}, error) {
+		if location.index == -1 {
+			return 0, nil, fmt.Errorf("%w: %s", ErrConfigurationItemNotFound, name)
+		}
+
+		return location.fileIndex, func(items []interface{}) []interface{} {
+			return append(items[:location.index], items[location.index+1:]...)
+		}, nil
+	})
+}
+
+// Apply the change to a copy of the raw settings of the config file selected by the plan function and build the changed
+// configuration. The config files using the layout of a previous version are written in the current version layout
+func (s *configurationStore) change(
+	kind ConfigurationItemKind,
+	parentName, name string,
+	plan func(files []*configurationFile, location *itemLocation) (int, func([]interface{}) []interface{}, error),
+) (*ApiKitServerConfiguration, func() error, error) {
+	files, err := readConfigurationFiles(s.path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	location, err := findItem(files, kind, parentName, name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fileIndex, mutate, err := plan(files, location)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	path := files[fileIndex].path
+	raw := copySettings(files[fileIndex].raw).(map[string]interface{})
+
+	container, key, _ := findItemsContainer(raw, kind, parentName, true)
+	items, _ := container[key].([]interface{})
+	container[key] = mutate(items)
+
+	if changedFile, err := createConfigurationFile(path, raw, nil); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidConfigurationChange, err)
+	} else {
+		files[fileIndex] = changedFile
+	}
+
+	config, err := buildServerConfiguration(files)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidConfigurationChange, err)
+	}
+
+	return config, func() error {
+		return writeConfigurationSettings(path, raw)
+	}, nil
+}
+
+// Location of a item in the config files, where the index is -1 if the item does not exist. The file index points to
+// the config file defining the item, the source of the source values, or the main config file for new items
+type itemLocation struct {
+	fileIndex int
+	index     int
+}
+
+func findItem(files []*configurationFile, kind ConfigurationItemKind, parentName, name string) (*itemLocation, error) {
+	location := &itemLocation{fileIndex: -1, index: -1}
+	for fileIndex, file := range files {
+		container, key, ok := findItemsContainer(file.raw, kind, parentName, false)
+		if !ok {
+			continue
+		}
+
+		if location.fileIndex == -1 {
+			location.fileIndex = fileIndex
+		}
+
+		for index, item := range settingsMapSlice(container, key) {
+			if fmt.Sprint(item["name"]) == name {
+				return &itemLocation{fileIndex: fileIndex, index: index}, nil
+			}
+		}
+	}
+
+	if location.fileIndex == -1 {
+		if kind == SourceValueItem {
+			return nil, fmt.Errorf("%w: source %s", ErrConfigurationItemNotFound, parentName)
+		}
+
+		location.fileIndex = 0
+	}
+
+	return location, nil
+}
+
+// Find the raw settings containing the collection of the items of the given kind. The missing general section is
+// created when requested
+func findItemsContainer(settings map[string]interface{}, kind ConfigurationItemKind, parentName string, create bool) (map[string]interface{}, string, bool) {
+	if kind == ApiKeyItem {
+		return settings, "api-keys", true
+	}
+
+	general, ok := settings["general"].(map[string]interface{})
+	if !ok {
+		if !create || kind == SourceValueItem {
+			return nil, "", false
+		}
+
+		general = make(map[string]interface{})
+		settings["general"] = general
+	}
+
+	switch kind {
+	case SourceItem:
+		return general, "sources", true
+	case EndpointItem:
+		return general, "endpoints", true
+	case SourceValueItem:
+		for _, source := range settingsMapSlice(general, "sources") {
+			if fmt.Sprint(source["name"]) == parentName {
+				return source, "values", true
+			}
+		}
+
+		return nil, "", false
+	default:
+		return nil, "", false
+	}
+}
+
+// Lowercase the keys of the item the same way the keys of the config files are handled and extract the item name
+func normalizeItem(item map[string]interface{}) (map[string]interface{}, string, error) {
+	normalized := lowercaseSettingsKeys(item).(map[string]interface{})
+
+	name, ok := normalized["name"].(string)
+	if !ok || len(name) == 0 {
+		return nil, "", fmt.Errorf("%w: missing item name", ErrInvalidConfigurationChange)
+	}
+
+	return normalized, name, nil
+}
+
+func lowercaseSettingsKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			result[strings.ToLower(key)] = lowercaseSettingsKeys(child)
+		}
+
+		return result
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, child := range v {
+			result = append(result, lowercaseSettingsKeys(child))
+		}
+
+		return result
+	default:
+		return value
+	}
+}
+
+// Create a copy of the item with the credentials replaced by the redaction placeholder. The api key secrets, the http
+// header values, the template parameter values and the user info of the source url are considered credentials
+func redactItem(kind ConfigurationItemKind, item map[string]interface{}) map[string]interface{} {
+	redacted := copySettings(item).(map[string]interface{})
+
+	switch kind {
+	case ApiKeyItem:
+		if _, ok := redacted["secret"]; ok {
+			redacted["secret"] = redactedSettingValue
+		}
+	case SourceItem:
+		for _, key := range []string{"http-headers", "parameters"} {
+			if settings, ok := redacted[key].(map[string]interface{}); ok {
+				for settingKey := range settings {
+					settings[settingKey] = redactedSettingValue
+				}
+			}
+		}
+
+		if url, ok := redacted["url"].(string); ok {
+			redacted["url"] = redactUrlUserInfo(url)
+		}
+	}
+
+	return redacted
+}
+
+// Replace the user info of the url with the redaction placeholder. The url is not parsed, as the raw setting may
+// contain environment variable references that are not valid url parts
+func redactUrlUserInfo(url string) string {
+	scheme, rest, ok := strings.Cut(url, "://")
+	if !ok {
+		return url
+	}
+
+	authority, path := rest, ""
+	if index := strings.IndexAny(rest, "/?#"); index != -1 {
+		authority, path = rest[:index], rest[index:]
+	}
+
+	index := strings.LastIndex(authority, "@")
+	if index == -1 {
+		return url
+	}
+
+	return fmt.Sprintf("%s://%s@%s%s", scheme, redactedSettingValue, authority[index+1:], path)
+}
+
+// Restore the settings of the changed item that are equal to the redacted settings of the stored item, so a item read
+// via the store can be written back without replacing the credentials with the placeholder. Any other setting carrying
+// the redaction placeholder is rejected
+func restoreRedactedSettings(kind ConfigurationItemKind, item, stored map[string]interface{}) error {
+	var redacted map[string]interface{}
+	if stored != nil {
+		redacted = redactItem(kind, stored)
+	}
+
+	return restoreRedactedValues(item, stored, redacted, "")
+}
+
+func restoreRedactedValues(item, stored, redacted map[string]interface{}, path string) error {
+	for key, value := range item {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			storedChild, _ := stored[key].(map[string]interface{})
+			redactedChild, _ := redacted[key].(map[string]interface{})
+
+			if err := restoreRedactedValues(v, storedChild, redactedChild, keyPath(path, key)); err != nil {
+				return err
+			}
+		case string:
+			if !strings.Contains(v, redactedSettingValue) {
+				continue
+			}
+
+			if redactedValue, ok := redacted[key].(string); ok && redactedValue == v {
+				item[key] = stored[key]
+				continue
+			}
+
+			return fmt.Errorf("%w: the %s setting contains the redaction placeholder", ErrInvalidConfigurationChange, keyPath(path, key))
+		}
+	}
+
+	return nil
+}
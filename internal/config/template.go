@@ -0,0 +1,366 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Krzysztofz01/apikit/internal/utils"
+)
+
+var templatePlaceholderRegex = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_-]+)\s*\}\}`)
+
+// Source options that can be provided via the global and group source defaults
+var sourceDefaultsKeys = []string{
+	"caching-enabled",
+	"caching-life-time-seconds",
+	"retries-count",
+	"timeout-seconds",
+}
+
+// Expand the sources instantiating templates into complete sources. The settings of a source are layered in the
+// following order, where later layers override earlier ones: global source defaults, group source defaults, template
+// and the source itself. The templates, groups and defaults can be defined in any of the config files
+func expandSourceTemplates(files []*configurationFile) error {
+	var (
+		defaults     map[string]interface{}
+		defaultsPath string
+	)
+
+	templates := make(map[string]map[string]interface{})
+	templateFiles := make(map[string]string)
+	groups := make(map[string]map[string]interface{})
+	groupFiles := make(map[string]string)
+
+	for _, file := range files {
+		general, ok := file.settings["general"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if fileDefaults, ok := general["source-defaults"]; ok {
+			if defaults != nil {
+				return fmt.Errorf("config: source defaults defined in the %s and %s config files", defaultsPath, file.path)
+			}
+
+			if fileDefaults, err := asSourceDefaults(fileDefaults); err != nil {
+				return fmt.Errorf("config: invalid source defaults in the %s config file: %w", file.path, err)
+			} else {
+				defaults, defaultsPath = fileDefaults, file.path
+			}
+		}
+
+		for _, group := range settingsMapSlice(general, "source-groups") {
+			name := fmt.Sprint(group["name"])
+			if previousPath, ok := groupFiles[name]; ok {
+				return fmt.Errorf("config: duplicate source group %s defined in the %s and %s config files", name, previousPath, file.path)
+			}
+
+			delete(group, "name")
+			if groupDefaults, err := asSourceDefaults(group); err != nil {
+				return fmt.Errorf("config: invalid %s source group in the %s config file: %w", name, file.path, err)
+			} else {
+				groups[name], groupFiles[name] = groupDefaults, file.path
+			}
+		}
+
+		for _, template := range settingsMapSlice(general, "source-templates") {
+			name := fmt.Sprint(template["name"])
+			if previousPath, ok := templateFiles[name]; ok {
+				return fmt.Errorf("config: duplicate source template %s defined in the %s and %s config files", name, previousPath, file.path)
+			}
+
+			delete(template, "name")
+			templates[name], templateFiles[name] = template, file.path
+		}
+
+		delete(general, "source-defaults")
+		delete(general, "source-groups")
+		delete(general, "source-templates")
+	}
+
+	for _, file := range files {
+		general, ok := file.settings["general"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		sources, ok := general["sources"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for index, source := range sources {
+			source, ok := source.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			expanded, substitutions, err := expandSource(source, defaults, groups, templates)
+			if err != nil {
+				return fmt.Errorf("config: failed to expand the %v source in the %s config file: %w", source["name"], file.path, err)
+			}
+
+			// NOTE: Only the parameter values substituted into the http headers are considered sensitive, as the other
+			// parameters (e.g. hosts and ports) are required in the logs to tell the sources apart
+			for _, substitution := range substitutions.Elements() {
+				if len(substitution) != 0 {
+					file.secrets = append(file.secrets, substitution)
+				}
+			}
+
+			// NOTE: The http headers are considered sensitive as they usually carry the credentials passed as parameters
+			if headers, ok := expanded["http-headers"].(map[string]interface{}); ok {
+				for _, header := range headers {
+					if header, ok := header.(string); ok && len(header) != 0 {
+						file.secrets = append(file.secrets, header)
+					}
+				}
+			}
+
+			if url, ok := expanded["url"].(string); ok {
+				file.secrets = append(file.secrets, urlUserInfoSecrets(url)...)
+			}
+
+			sources[index] = expanded
+		}
+	}
+
+	return nil
+}
+
+// Expand the source into a complete source. The parameter values substituted into the template http headers are returned alongside
+func expandSource(source, defaults map[string]interface{}, groups, templates map[string]map[string]interface{}) (map[string]interface{}, utils.Set[string], error) {
+	expanded := make(map[string]interface{})
+	mergeSettings(expanded, defaults)
+
+	substitutions := utils.NewEmptySet[string]()
+
+	var template map[string]interface{}
+	if templateName, ok := source["template"]; ok {
+		if template, ok = templates[fmt.Sprint(templateName)]; !ok {
+			return nil, nil, fmt.Errorf("config: source references non existing template %v", templateName)
+		}
+
+		parameters, _ := source["parameters"].(map[string]interface{})
+		if instance, err := instantiateTemplate(template, parameters, substitutions); err != nil {
+			return nil, nil, fmt.Errorf("config: failed to instantiate the %v template: %w", templateName, err)
+		} else {
+			template = instance
+		}
+	} else if _, ok := source["parameters"]; ok {
+		return nil, nil, fmt.Errorf("config: source parameters require a template")
+	}
+
+	// NOTE: The group can be assigned by the template and overridden by the source
+	groupName, ok := source["group"]
+	if !ok && template != nil {
+		groupName, ok = template["group"]
+	}
+
+	if ok {
+		if group, ok := groups[fmt.Sprint(groupName)]; !ok {
+			return nil, nil, fmt.Errorf("config: source references non existing group %v", groupName)
+		} else {
+			mergeSettings(expanded, group)
+		}
+	}
+
+	mergeSettings(expanded, template)
+	mergeSettings(expanded, source)
+
+	delete(expanded, "template")
+	delete(expanded, "parameters")
+	delete(expanded, "group")
+
+	return expanded, substitutions, nil
+}
+
+// Create a copy of the template with the placeholders replaced by the parameter values. The template parameters map
+// the parameter names to their default values, where a missing default marks the parameter as required. The parameter
+// values substituted into the http headers are added to the substitutions set
+func instantiateTemplate(template, parameters map[string]interface{}, substitutions utils.Set[string]) (map[string]interface{}, error) {
+	declared, _ := template["parameters"].(map[string]interface{})
+
+	values := make(map[string]string, len(declared))
+	for name, defaultValue := range declared {
+		if value, ok := parameters[name]; ok {
+			values[name] = fmt.Sprint(value)
+		} else if defaultValue != nil {
+			values[name] = fmt.Sprint(defaultValue)
+		} else {
+			return nil, fmt.Errorf("config: missing the required %s template parameter", name)
+		}
+	}
+
+	for name := range parameters {
+		if _, ok := declared[name]; !ok {
+			return nil, fmt.Errorf("config: unknown %s template parameter", name)
+		}
+	}
+
+	instance, err := substitutePlaceholders(template, false, values, substitutions)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(instance.(map[string]interface{}), "parameters")
+	return instance.(map[string]interface{}), nil
+}
+
+func substitutePlaceholders(value interface{}, sensitive bool, parameters map[string]string, substitutions utils.Set[string]) (interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			// NOTE: The parameters declaration itself is not a subject of the substitution
+			if key == "parameters" {
+				result[key] = child
+				continue
+			}
+
+			if substituted, err := substitutePlaceholders(child, sensitive || key == "http-headers", parameters, substitutions); err != nil {
+				return nil, err
+			} else {
+				result[key] = substituted
+			}
+		}
+
+		return result, nil
+	case []interface{}:
+		result := make([]interface{}, 0, len(v))
+		for _, child := range v {
+			if substituted, err := substitutePlaceholders(child, sensitive, parameters, substitutions); err != nil {
+				return nil, err
+			} else {
+				result = append(result, substituted)
+			}
+		}
+
+		return result, nil
+	case string:
+		var substituteErr error
+		result := templatePlaceholderRegex.ReplaceAllStringFunc(v, func(placeholder string) string {
+			name := strings.ToLower(templatePlaceholderRegex.FindStringSubmatch(placeholder)[1])
+
+			if value, ok := parameters[name]; ok {
+				if sensitive {
+					substitutions.Add(value)
+				}
+
+				return value
+			}
+
+			if substituteErr == nil {
+				substituteErr = fmt.Errorf("config: template placeholder references undeclared %s parameter", name)
+			}
+
+			return placeholder
+		})
+
+		return result, substituteErr
+	default:
+		return value, nil
+	}
+}
+
+// Merge the overriding settings into the target settings. The source values are merged by their names, so a source can
+// override individual fields of the template values and add new values
+func mergeSettings(target, override map[string]interface{}) {
+	for key, value := range override {
+		if key == "values" {
+			if targetValues, ok := target[key].([]interface{}); ok {
+				if overrideValues, ok := value.([]interface{}); ok {
+					target[key] = mergeSourceValues(targetValues, overrideValues)
+					continue
+				}
+			}
+		}
+
+		if targetMap, ok := target[key].(map[string]interface{}); ok {
+			if overrideMap, ok := value.(map[string]interface{}); ok {
+				merged := make(map[string]interface{}, len(targetMap)+len(overrideMap))
+				mergeSettings(merged, targetMap)
+				mergeSettings(merged, overrideMap)
+
+				target[key] = merged
+				continue
+			}
+		}
+
+		target[key] = value
+	}
+}
+
+func mergeSourceValues(values, overrideValues []interface{}) []interface{} {
+	merged := make([]interface{}, 0, len(values)+len(overrideValues))
+	indexes := make(map[string]int, len(values))
+	for _, value := range values {
+		if value, ok := value.(map[string]interface{}); ok {
+			indexes[fmt.Sprint(value["name"])] = len(merged)
+		}
+
+		merged = append(merged, value)
+	}
+
+	for _, overrideValue := range overrideValues {
+		overrideMap, ok := overrideValue.(map[string]interface{})
+		if !ok {
+			merged = append(merged, overrideValue)
+			continue
+		}
+
+		index, ok := indexes[fmt.Sprint(overrideMap["name"])]
+		if !ok {
+			merged = append(merged, overrideValue)
+			continue
+		}
+
+		value := make(map[string]interface{})
+		mergeSettings(value, merged[index].(map[string]interface{}))
+		mergeSettings(value, overrideMap)
+
+		merged[index] = value
+	}
+
+	return merged
+}
+
+func asSourceDefaults(value interface{}) (map[string]interface{}, error) {
+	defaults, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("config: source defaults must be a object")
+	}
+
+	for key := range defaults {
+		supported := false
+		for _, defaultsKey := range sourceDefaultsKeys {
+			if key == defaultsKey {
+				supported = true
+				break
+			}
+		}
+
+		if !supported {
+			return nil, fmt.Errorf("config: unsupported %s source default", key)
+		}
+	}
+
+	return defaults, nil
+}
+
+func settingsMapSlice(settings map[string]interface{}, key string) []map[string]interface{} {
+	values, ok := settings[key].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	result := make([]map[string]interface{}, 0, len(values))
+	for _, value := range values {
+		if value, ok := value.(map[string]interface{}); ok {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Problem of a config item addressed by the config path of the item (e.g. general.sources[3].values[7].xpath)
+type ValidationError struct {
+	File    string `json:"file,omitempty"`
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e *ValidationError) Error() string {
+	if len(e.Path) == 0 {
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+
+	if len(e.File) != 0 {
+		return fmt.Sprintf("%s: %s: %s", e.File, e.Path, e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// All problems found during the validation of a configuration
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationErrors) add(path, message string) {
+	*e = append(*e, &ValidationError{Path: path, Message: message})
+}
+
+// Create the config path of the given key of the config item at the given path
+func keyPath(path, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+
+	return fmt.Sprintf("%s.%s", path, key)
+}
+
+// Create the config path of the element at the given index of the collection under the given key
+func indexPath(path, key string, index int) string {
+	return fmt.Sprintf("%s[%d]", keyPath(path, key), index)
+}
+
+var mergedCollectionPathRegex = regexp.MustCompile(`^(general\.sources|general\.endpoints|general\.mappings|endpoints|api-keys)\[(\d+)\]`)
+
+// Attribute the validation errors of the merged configuration to the config files. The collection indexes of the merged
+// configuration paths are translated to the indexes of the collections in the defining config file
+func locateValidationErrors(errs ValidationErrors, configs []*ApiKitServerConfiguration) {
+	collectionLengths := func(config *ApiKitServerConfiguration) map[string]int {
+		return map[string]int{
+			"general.sources":   len(config.ApiKit.Sources),
+			"general.endpoints": len(config.ApiKit.Endpoints),
+			"general.mappings":  len(config.ApiKit.Mappings),
+			"endpoints":         len(config.Endpoints),
+			"api-keys":          len(config.ApiKeys),
+		}
+	}
+
+	for _, err := range errs {
+		err.File = configs[0].Files[0]
+
+		matches := mergedCollectionPathRegex.FindStringSubmatch(err.Path)
+		if matches == nil {
+			continue
+		}
+
+		collection := matches[1]
+		index, _ := strconv.Atoi(matches[2])
+
+		for _, config := range configs {
+			length := collectionLengths(config)[collection]
+			if index < length {
+				err.File = config.Files[0]
+				err.Path = fmt.Sprintf("%s[%d]%s", collection, index, err.Path[len(matches[0]):])
+				break
+			}
+
+			index -= length
+		}
+	}
+}
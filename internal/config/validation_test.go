@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestServerConfiguration() *ApiKitServerConfiguration {
+	return &ApiKitServerConfiguration{
+		ApiKit: &ApiKitConfiguration{
+			Sources: []*SourceConfiguration{
+				{Name: "router", Values: []*SourceValueConfiguration{
+					{Name: "uptime", Xpath: "//td[@id='uptime']", Type: Int, Required: true},
+					{Name: "model", Xpath: "//td[@id='model']", Required: true},
+				}},
+				{Name: "switch", Values: []*SourceValueConfiguration{}},
+			},
+			Endpoints: []*EndpointConfiguration{
+				{Name: "router", Values: []*EndpointValueConfiguration{{Name: "uptime", SourceName: "router", SourceValueName: "uptime"}}},
+			},
+		},
+		Endpoints: []*ApiKitServerEndpointConfiguration{
+			{EndpointName: "router", Path: "/router", RequiredApiKeyPool: []string{"admin"}},
+		},
+		ApiKeys: []*ApiKitServerKeyConfiguration{{Name: "admin", Secret: "admin"}, {Name: "guest", Secret: "guest"}},
+		Host:    "localhost:8080",
+	}
+}
+
+func TestValidateServerErrorsShouldReportStaticProblemsWithPaths(t *testing.T) {
+	cases := []struct {
+		modify func(c *ApiKitServerConfiguration)
+		path   string
+	}{
+		{modify: func(c *ApiKitServerConfiguration) { c.ApiKit.Sources[0].Values[1].Xpath = "//td[@id=" }, path: "general.sources[0].values[1].xpath"},
+		{modify: func(c *ApiKitServerConfiguration) {
+			c.ApiKit.Sources[0].Values[1].ExtractionRegex = `v(\d+)`
+			c.ApiKit.Sources[0].Values[1].ExtractionRegexIndex = 2
+		}, path: "general.sources[0].values[1].extraction-regex-match-index"},
+		{modify: func(c *ApiKitServerConfiguration) { c.Endpoints[0].EndpointName = "switch" }, path: "endpoints[0].name"},
+		{modify: func(c *ApiKitServerConfiguration) {
+			c.Endpoints = append(c.Endpoints, &ApiKitServerEndpointConfiguration{EndpointName: "router", Path: "/router", RequiredApiKeyPool: []string{}})
+		}, path: "endpoints[1].path"},
+		{modify: func(c *ApiKitServerConfiguration) {
+			c.ApiKit.Endpoints = append(c.ApiKit.Endpoints, &EndpointConfiguration{Name: "router"})
+		}, path: "general.endpoints[1].name"},
+		{modify: func(c *ApiKitServerConfiguration) { c.ApiKeys[1].Secret = "" }, path: "api-keys[1].secret"},
+	}
+
+	assert.Empty(t, ValidateServerErrors(createTestServerConfiguration()))
+
+	for _, c := range cases {
+		config := createTestServerConfiguration()
+		c.modify(config)
+
+		errs := ValidateServerErrors(config)
+
+		assert.Len(t, errs, 1)
+		assert.Equal(t, c.path, errs[0].Path)
+	}
+}
+
+func TestValidateServerWarningsShouldReportUnusedItems(t *testing.T) {
+	warnings := ValidateServerWarnings(createTestServerConfiguration())
+
+	paths := make([]string, 0, len(warnings))
+	for _, warning := range warnings {
+		paths = append(paths, warning.Path)
+	}
+
+	assert.Equal(t, []string{"general.sources[0].values[1]", "general.sources[1]", "api-keys[1]"}, paths)
+}
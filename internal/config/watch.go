@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Krzysztofz01/apikit/internal/utils"
+	"github.com/fsnotify/fsnotify"
+)
+
+const watchDebounceDuration = 500 * time.Millisecond
+
+// Watcher of the configuration files that invokes the change callback once a burst of file events settles. The parent
+// directories are watched in order to also detect files replaced via rename by editors
+type ConfigurationWatcher interface {
+	// Replace the watched config files and include directories. The include directories are watched for created config
+	// files, which are matched by the include patterns only after they are created
+	Watch(files []string, includeDirectories []string) error
+
+	// Stop the watch
+	Close() error
+}
+
+type configurationWatcher struct {
+	watcher  *fsnotify.Watcher
+	onChange func()
+	onError  func(error)
+
+	mu          sync.Mutex
+	files       utils.Set[string]
+	includeDirs utils.Set[string]
+	watchedDirs utils.Set[string]
+	debounce    *time.Timer
+}
+
+func (w *configurationWatcher) Watch(files []string, includeDirectories []string) error {
+	absFiles, err := absPathsSet(files)
+	if err != nil {
+		return fmt.Errorf("config: failed to resolve the absolute config file path: %w", err)
+	}
+
+	absIncludeDirs, err := absPathsSet(includeDirectories)
+	if err != nil {
+		return fmt.Errorf("config: failed to resolve the absolute include directory path: %w", err)
+	}
+
+	dirs := utils.NewEmptySet[string]()
+	for _, file := range absFiles.Elements() {
+		dirs.Add(filepath.Dir(file))
+	}
+
+	for _, dir := range absIncludeDirs.Elements() {
+		dirs.Add(dir)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, dir := range dirs.Elements() {
+		if w.watchedDirs.Contains(dir) {
+			continue
+		}
+
+		if err := w.watcher.Add(dir); err != nil {
+			return fmt.Errorf("config: failed to watch the config file directory: %w", err)
+		}
+
+		w.watchedDirs.Add(dir)
+	}
+
+	for _, dir := range w.watchedDirs.Elements() {
+		if dirs.Contains(dir) {
+			continue
+		}
+
+		// NOTE: The directory could have been removed, which also removes the watch
+		_ = w.watcher.Remove(dir)
+		w.watchedDirs.Remove(dir)
+	}
+
+	w.files = absFiles
+	w.includeDirs = absIncludeDirs
+	return nil
+}
+
+func (w *configurationWatcher) Close() error {
+	return w.watcher.Close()
+}
+
+func (w *configurationWatcher) handleEvent(event fsnotify.Event) {
+	path := filepath.Clean(event.Name)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	watchedFileChanged := w.files.Contains(path) && event.Has(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove)
+	includedFileCreated := w.includeDirs.Contains(filepath.Dir(path)) && isSupportedConfigFile(path) && event.Has(fsnotify.Create)
+	if !watchedFileChanged && !includedFileCreated {
+		return
+	}
+
+	if w.debounce != nil {
+		w.debounce.Stop()
+	}
+
+	w.debounce = time.AfterFunc(watchDebounceDuration, w.onChange)
+}
+
+func (w *configurationWatcher) run() {
+	for {
+		select {
+		case event, ok := <-w.watcher.Events:
+			if !ok {
+				return
+			}
+
+			w.handleEvent(event)
+		case err, ok := <-w.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			w.onError(fmt.Errorf("config: config file watcher failure: %w", err))
+		}
+	}
+}
+
+func absPathsSet(paths []string) (utils.Set[string], error) {
+	absPaths := utils.NewEmptySet[string]()
+	for _, path := range paths {
+		if absPath, err := filepath.Abs(path); err != nil {
+			return nil, err
+		} else {
+			absPaths.Add(absPath)
+		}
+	}
+
+	return absPaths, nil
+}
+
+// Create a watcher of the given configuration files and include directories
+func CreateConfigurationWatcher(files []string, includeDirectories []string, onChange func(), onError func(error)) (ConfigurationWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("config: failed to create the config file watcher: %w", err)
+	}
+
+	w := &configurationWatcher{
+		watcher:     watcher,
+		onChange:    onChange,
+		onError:     onError,
+		files:       utils.NewEmptySet[string](),
+		includeDirs: utils.NewEmptySet[string](),
+		watchedDirs: utils.NewEmptySet[string](),
+	}
+
+	if err := w.Watch(files, includeDirectories); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	go w.run()
+
+	return w, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForConfigurationChange(changes <-chan struct{}) bool {
+	select {
+	case <-changes:
+		return true
+	case <-time.After(5 * time.Second):
+		return false
+	}
+}
+
+func TestConfigurationWatcherShouldDetectChangesOfIncludedFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "conf.d"), 0o700))
+
+	path := filepath.Join(dir, "config.yaml")
+	assert.Nil(t, os.WriteFile(path, []byte("host: localhost:8080\ninclude: [conf.d]\n"), 0o600))
+
+	config, err := LoadServerConfigurationFromFile(path)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "conf.d")}, config.IncludeDirs)
+
+	changes := make(chan struct{}, 8)
+	watcher, err := CreateConfigurationWatcher(config.Files, config.IncludeDirs, func() { changes <- struct{}{} }, func(err error) {})
+	assert.Nil(t, err)
+	defer watcher.Close()
+
+	// NOTE: The created file is matched by the include pattern, so the change is detected before the file is watched
+	includedPath := filepath.Join(dir, "conf.d", "switch.yaml")
+	assert.Nil(t, os.WriteFile(includedPath, []byte("general:\n  sources:\n    - name: switch\n"), 0o600))
+	assert.True(t, waitForConfigurationChange(changes))
+
+	config, err = LoadServerConfigurationFromFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, config.Files, includedPath)
+	assert.Nil(t, watcher.Watch(config.Files, config.IncludeDirs))
+
+	assert.Nil(t, os.WriteFile(includedPath, []byte("general:\n  sources:\n    - name: router\n"), 0o600))
+	assert.True(t, waitForConfigurationChange(changes))
+}
@@ -2,7 +2,6 @@ package content
 
 import (
 	"fmt"
-	"math"
 	"strconv"
 	"strings"
 
@@ -11,9 +10,9 @@ import (
 )
 
 type HtmlContent interface {
-	GetFirstElement(xpath string) (HtmlContentElement, bool, error)
-	GetSingleElement(xpath string) (HtmlContentElement, bool, error)
-	GetAllElements(xpath string) ([]HtmlContentElement, error)
+	GetFirstElement(selector HtmlContentSelector) (HtmlContentElement, bool, error)
+	GetSingleElement(selector HtmlContentSelector) (HtmlContentElement, bool, error)
+	GetAllElements(selector HtmlContentSelector) ([]HtmlContentElement, error)
 	GetRawContent() (string, error)
 }
 
@@ -38,12 +37,13 @@ func CreateHtmlContent(html string) (HtmlContent, error) {
 	}, nil
 }
 
-func (h *htmlContent) GetAllElements(xpath string) ([]HtmlContentElement, error) {
-	nodes, err := htmlquery.QueryAll(h.document, xpath)
-	if err != nil {
-		return nil, fmt.Errorf("content: failed to query for nodes: %w", err)
+func (h *htmlContent) GetAllElements(selector HtmlContentSelector) ([]HtmlContentElement, error) {
+	if selector == nil {
+		return nil, fmt.Errorf("content: invalid nil reference selector provided")
 	}
 
+	nodes := selector.QueryAll(h.document)
+
 	elements := make([]HtmlContentElement, 0, len(nodes))
 	for _, node := range nodes {
 		if element, err := createHtmlContentElement(node); err != nil {
@@ -56,15 +56,12 @@ func (h *htmlContent) GetAllElements(xpath string) ([]HtmlContentElement, error)
 	return elements, nil
 }
 
-func (h *htmlContent) GetFirstElement(xpath string) (HtmlContentElement, bool, error) {
-	a, _ := h.GetRawContent()
-	_ = a
-
-	node, err := htmlquery.Query(h.document, xpath)
-	if err != nil {
-		return nil, false, fmt.Errorf("content: failed to query for the first node: %w", err)
+func (h *htmlContent) GetFirstElement(selector HtmlContentSelector) (HtmlContentElement, bool, error) {
+	if selector == nil {
+		return nil, false, fmt.Errorf("content: invalid nil reference selector provided")
 	}
 
+	node := selector.QueryFirst(h.document)
 	if node == nil {
 		return nil, false, nil
 	}
@@ -80,14 +77,18 @@ func (h *htmlContent) GetRawContent() (string, error) {
 	return htmlquery.OutputHTML(h.document, true), nil
 }
 
-func (h *htmlContent) GetSingleElement(xpath string) (HtmlContentElement, bool, error) {
-	nodes, err := htmlquery.QueryAll(h.document, xpath)
-	if err != nil {
-		return nil, false, fmt.Errorf("content: failed to query for nodes: %w", err)
+func (h *htmlContent) GetSingleElement(selector HtmlContentSelector) (HtmlContentElement, bool, error) {
+	if selector == nil {
+		return nil, false, fmt.Errorf("content: invalid nil reference selector provided")
+	}
+
+	nodes := selector.QueryAll(h.document)
+	if len(nodes) == 0 {
+		return nil, false, nil
 	}
 
 	if len(nodes) != 1 {
-		return nil, true, fmt.Errorf("content: multiple matching nodes found: %w", err)
+		return nil, true, fmt.Errorf("content: multiple matching nodes found")
 	}
 
 	if element, err := createHtmlContentElement(nodes[0]); err != nil {
@@ -99,13 +100,30 @@ func (h *htmlContent) GetSingleElement(xpath string) (HtmlContentElement, bool,
 
 type HtmlContentValuePreprocess func(in string) (string, error)
 
+type HtmlContentMode int
+
+const (
+	// Concatenated text nodes of the element and its descendants with normalized whitespace
+	Text HtmlContentMode = iota
+	// Markup of the element children
+	InnerHtml
+	// Markup of the element including the element itself
+	OuterHtml
+	// Concatenated direct text node children of the element with normalized whitespace
+	OwnText
+)
+
 type HtmlContentElement interface {
-	GetInnerTextString(preprocess HtmlContentValuePreprocess) (string, error)
-	GetInnerTextInt(preprocess HtmlContentValuePreprocess) (int, error)
-	GetInnerTextFloat(preprocess HtmlContentValuePreprocess) (float64, error)
+	GetContentString(mode HtmlContentMode, preprocess HtmlContentValuePreprocess) (string, error)
+	GetContentInt(mode HtmlContentMode, preprocess HtmlContentValuePreprocess) (int, error)
+	GetContentFloat(mode HtmlContentMode, preprocess HtmlContentValuePreprocess) (float64, error)
 	GetAttributeValueString(name string, preprocess HtmlContentValuePreprocess) (string, error)
 	GetAttributeValueInt(name string, preprocess HtmlContentValuePreprocess) (int, error)
 	GetAttributeValueFloat(name string, preprocess HtmlContentValuePreprocess) (float64, error)
+	GetStylePropertyString(name string, preprocess HtmlContentValuePreprocess) (string, error)
+	GetStylePropertyInt(name string, preprocess HtmlContentValuePreprocess) (int, error)
+	GetStylePropertyFloat(name string, preprocess HtmlContentValuePreprocess) (float64, error)
+	GetTableRows() ([][]HtmlContentElement, error)
 }
 
 type htmlContentElement struct {
@@ -145,13 +163,104 @@ func (h *htmlContentElement) GetAttributeValue(name string, preprocess HtmlConte
 	return value, nil
 }
 
-func (h *htmlContentElement) GetInnerText(preprocess HtmlContentValuePreprocess) (string, error) {
-	value := htmlquery.OutputHTML(h.node, false)
+func (h *htmlContentElement) GetTableRows() ([][]HtmlContentElement, error) {
+	if h.node.Type != html.ElementNode || h.node.Data != "table" {
+		return nil, fmt.Errorf("content: the element is not a table")
+	}
+
+	rows := make([][]HtmlContentElement, 0)
+
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			if child.Type != html.ElementNode {
+				continue
+			}
+
+			switch child.Data {
+			case "thead", "tbody", "tfoot":
+				collect(child)
+			case "tr":
+				cells := make([]HtmlContentElement, 0)
+				for cell := child.FirstChild; cell != nil; cell = cell.NextSibling {
+					if cell.Type == html.ElementNode && (cell.Data == "td" || cell.Data == "th") {
+						cells = append(cells, &htmlContentElement{node: cell})
+					}
+				}
+
+				rows = append(rows, cells)
+			}
+		}
+	}
+
+	collect(h.node)
+	return rows, nil
+}
+
+func (h *htmlContentElement) GetStyleProperty(name string, preprocess HtmlContentValuePreprocess) (string, error) {
+	if len(name) == 0 {
+		return "", fmt.Errorf("content: invalid style property name provided")
+	}
+
+	value, ok := ParseStyleProperty(htmlquery.SelectAttr(h.node, "style"), name)
+	if !ok {
+		return "", fmt.Errorf("content: style property not present on the element")
+	}
+
+	if preprocess != nil {
+		return func() (preprocessValue string, err error) {
+			defer func() {
+				if panicErr := recover(); panicErr != nil {
+					err = fmt.Errorf("content: style property value preprocessing failed: %s", panicErr)
+				}
+			}()
+
+			preprocessValue, err = preprocess(value)
+			return
+		}()
+	}
+
+	return value, nil
+}
+
+// Find the value of a given property in a inline style declaration list. The property name matching is case-insensitive
+func ParseStyleProperty(style string, name string) (string, bool) {
+	for _, declaration := range strings.Split(style, ";") {
+		property, value, ok := strings.Cut(declaration, ":")
+		if !ok {
+			continue
+		}
+
+		if strings.EqualFold(strings.TrimSpace(property), name) {
+			value = strings.TrimSpace(value)
+			value = strings.TrimSpace(strings.TrimSuffix(value, "!important"))
+			return value, true
+		}
+	}
+
+	return "", false
+}
+
+func (h *htmlContentElement) GetContent(mode HtmlContentMode, preprocess HtmlContentValuePreprocess) (string, error) {
+	var value string
+	switch mode {
+	case Text:
+		value = normalizeWhitespace(collectText(h.node, true))
+	case InnerHtml:
+		value = htmlquery.OutputHTML(h.node, false)
+	case OuterHtml:
+		value = htmlquery.OutputHTML(h.node, true)
+	case OwnText:
+		value = normalizeWhitespace(collectText(h.node, false))
+	default:
+		return "", fmt.Errorf("content: invalid content mode provided")
+	}
+
 	if preprocess != nil {
 		return func() (preprocessValue string, err error) {
 			defer func() {
 				if panicErr := recover(); panicErr != nil {
-					err = fmt.Errorf("content: inner text value preprocessing failed: %s", panicErr)
+					err = fmt.Errorf("content: content value preprocessing failed: %s", panicErr)
 				}
 			}()
 
@@ -163,6 +272,33 @@ func (h *htmlContentElement) GetInnerText(preprocess HtmlContentValuePreprocess)
 	return value, nil
 }
 
+func collectText(node *html.Node, recursive bool) string {
+	builder := strings.Builder{}
+
+	var collect func(n *html.Node)
+	collect = func(n *html.Node) {
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			switch child.Type {
+			case html.TextNode:
+				builder.WriteString(child.Data)
+			case html.ElementNode:
+				if child.Data == "br" {
+					builder.WriteString(" ")
+				} else if recursive && child.Data != "script" && child.Data != "style" {
+					collect(child)
+				}
+			}
+		}
+	}
+
+	collect(node)
+	return builder.String()
+}
+
+func normalizeWhitespace(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
 func (h *htmlContentElement) GetAttributeValueFloat(name string, preprocess HtmlContentValuePreprocess) (float64, error) {
 	value, err := h.GetAttributeValue(name, preprocess)
 	if err != nil {
@@ -187,10 +323,6 @@ func (h *htmlContentElement) GetAttributeValueInt(name string, preprocess HtmlCo
 		return 0, fmt.Errorf("content: failed to parse the attribute value as int: %w", err)
 	}
 
-	if valueI64 > math.MaxInt32 {
-		return 0, fmt.Errorf("content: the target integer value is overflowing")
-	}
-
 	return int(valueI64), nil
 }
 
@@ -202,40 +334,71 @@ func (h *htmlContentElement) GetAttributeValueString(name string, preprocess Htm
 	}
 }
 
-func (h *htmlContentElement) GetInnerTextFloat(preprocess HtmlContentValuePreprocess) (float64, error) {
-	value, err := h.GetInnerText(preprocess)
+func (h *htmlContentElement) GetStylePropertyFloat(name string, preprocess HtmlContentValuePreprocess) (float64, error) {
+	value, err := h.GetStyleProperty(name, preprocess)
 	if err != nil {
-		return 0, fmt.Errorf("content: failed to access inner text float value: %w", err)
+		return 0, fmt.Errorf("content: failed to access style property float value: %w", err)
 	}
 
 	if valueF, err := strconv.ParseFloat(value, 64); err != nil {
-		return 0, fmt.Errorf("content: failed to parse the inner text value as float64: %w", err)
+		return 0, fmt.Errorf("content: failed to parse the style property value as float64: %w", err)
 	} else {
 		return valueF, nil
 	}
 }
 
-func (h *htmlContentElement) GetInnerTextInt(preprocess HtmlContentValuePreprocess) (int, error) {
-	value, err := h.GetInnerText(preprocess)
+func (h *htmlContentElement) GetStylePropertyInt(name string, preprocess HtmlContentValuePreprocess) (int, error) {
+	value, err := h.GetStyleProperty(name, preprocess)
 	if err != nil {
-		return 0, fmt.Errorf("content: failed to access inner text int value: %w", err)
+		return 0, fmt.Errorf("content: failed to access style property int value: %w", err)
 	}
 
 	valueI64, err := strconv.ParseInt(value, 10, 0)
 	if err != nil {
-		return 0, fmt.Errorf("content: failed to parse the inner text value as int: %w", err)
+		return 0, fmt.Errorf("content: failed to parse the style property value as int: %w", err)
+	}
+
+	return int(valueI64), nil
+}
+
+func (h *htmlContentElement) GetStylePropertyString(name string, preprocess HtmlContentValuePreprocess) (string, error) {
+	if value, err := h.GetStyleProperty(name, preprocess); err != nil {
+		return "", fmt.Errorf("content: failed to access style property string value: %w", err)
+	} else {
+		return value, nil
+	}
+}
+
+func (h *htmlContentElement) GetContentFloat(mode HtmlContentMode, preprocess HtmlContentValuePreprocess) (float64, error) {
+	value, err := h.GetContent(mode, preprocess)
+	if err != nil {
+		return 0, fmt.Errorf("content: failed to access content float value: %w", err)
+	}
+
+	if valueF, err := strconv.ParseFloat(value, 64); err != nil {
+		return 0, fmt.Errorf("content: failed to parse the content value as float64: %w", err)
+	} else {
+		return valueF, nil
+	}
+}
+
+func (h *htmlContentElement) GetContentInt(mode HtmlContentMode, preprocess HtmlContentValuePreprocess) (int, error) {
+	value, err := h.GetContent(mode, preprocess)
+	if err != nil {
+		return 0, fmt.Errorf("content: failed to access content int value: %w", err)
 	}
 
-	if valueI64 > math.MaxInt32 {
-		return 0, fmt.Errorf("content: the target integer value is overflowing")
+	valueI64, err := strconv.ParseInt(value, 10, 0)
+	if err != nil {
+		return 0, fmt.Errorf("content: failed to parse the content value as int: %w", err)
 	}
 
 	return int(valueI64), nil
 }
 
-func (h *htmlContentElement) GetInnerTextString(preprocess HtmlContentValuePreprocess) (string, error) {
-	if value, err := h.GetInnerText(preprocess); err != nil {
-		return "", fmt.Errorf("content: failed to access inner text string value: %w", err)
+func (h *htmlContentElement) GetContentString(mode HtmlContentMode, preprocess HtmlContentValuePreprocess) (string, error) {
+	if value, err := h.GetContent(mode, preprocess); err != nil {
+		return "", fmt.Errorf("content: failed to access content string value: %w", err)
 	} else {
 		return value, nil
 	}
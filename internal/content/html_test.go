@@ -0,0 +1,91 @@
+package content
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseStylePropertyShouldReturnCorrectValue(t *testing.T) {
+	cases := []struct {
+		style         string
+		name          string
+		expected      string
+		expectedFound bool
+	}{
+		{style: "width: 72%", name: "width", expected: "72%", expectedFound: true},
+		{style: "color:red; WIDTH : 10px !important;", name: "width", expected: "10px", expectedFound: true},
+		{style: "color: red", name: "width", expected: "", expectedFound: false},
+		{style: "", name: "width", expected: "", expectedFound: false},
+	}
+
+	for _, c := range cases {
+		actual, found := ParseStyleProperty(c.style, c.name)
+
+		assert.Equal(t, c.expectedFound, found)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestGetContentShouldReturnCorrectValueForEachMode(t *testing.T) {
+	html, err := CreateHtmlContent(`<html><body><div id="value">  12<b>.5</b> <br>dBm <span>x</span></div></body></html>`)
+	assert.Nil(t, err)
+
+	selector, err := CreateXpathSelector("//div[@id='value']")
+	assert.Nil(t, err)
+
+	element, found, err := html.GetFirstElement(selector)
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	cases := []struct {
+		mode     HtmlContentMode
+		expected string
+	}{
+		{mode: Text, expected: "12.5 dBm x"},
+		{mode: OwnText, expected: "12 dBm"},
+		{mode: InnerHtml, expected: "  12<b>.5</b> <br/>dBm <span>x</span>"},
+		{mode: OuterHtml, expected: `<div id="value">  12<b>.5</b> <br/>dBm <span>x</span></div>`},
+	}
+
+	for _, c := range cases {
+		actual, err := element.GetContentString(c.mode, nil)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestGetTableRowsShouldReturnRowsWithoutNestedTables(t *testing.T) {
+	html, err := CreateHtmlContent(`<html><body><table id="leases">
+<thead><tr><th>Host</th><th>IP</th></tr></thead>
+<tbody>
+<tr><td>printer</td><td>10.0.0.2</td></tr>
+<tr><td><table><tr><td>nested</td></tr></table></td><td>10.0.0.3</td></tr>
+</tbody>
+</table></body></html>`)
+	assert.Nil(t, err)
+
+	selector, err := CreateCssSelector("#leases")
+	assert.Nil(t, err)
+
+	element, found, err := html.GetFirstElement(selector)
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	rows, err := element.GetTableRows()
+	assert.Nil(t, err)
+	assert.Len(t, rows, 3)
+
+	expected := [][]string{{"Host", "IP"}, {"printer", "10.0.0.2"}, {"nested", "10.0.0.3"}}
+	for rowIndex, row := range rows {
+		assert.Len(t, row, 2)
+
+		for cellIndex, cell := range row {
+			actual, err := cell.GetContentString(Text, nil)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected[rowIndex][cellIndex], actual)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package content
+
+import (
+	"fmt"
+
+	"github.com/andybalholm/cascadia"
+	"github.com/antchfx/htmlquery"
+	"github.com/antchfx/xpath"
+	"golang.org/x/net/html"
+)
+
+type HtmlContentSelector interface {
+	QueryFirst(node *html.Node) *html.Node
+	QueryAll(node *html.Node) []*html.Node
+	String() string
+}
+
+type xpathSelector struct {
+	expression string
+	expr       *xpath.Expr
+}
+
+func CreateXpathSelector(expression string) (HtmlContentSelector, error) {
+	if len(expression) == 0 {
+		return nil, fmt.Errorf("content: invalid empty xpath expression provided")
+	}
+
+	expr, err := xpath.Compile(expression)
+	if err != nil {
+		return nil, fmt.Errorf("content: failed to compile the xpath expression: %w", err)
+	}
+
+	return &xpathSelector{
+		expression: expression,
+		expr:       expr,
+	}, nil
+}
+
+func (s *xpathSelector) QueryFirst(node *html.Node) *html.Node {
+	return htmlquery.QuerySelector(node, s.expr)
+}
+
+func (s *xpathSelector) QueryAll(node *html.Node) []*html.Node {
+	return htmlquery.QuerySelectorAll(node, s.expr)
+}
+
+func (s *xpathSelector) String() string {
+	return s.expression
+}
+
+type cssSelector struct {
+	expression string
+	sel        cascadia.Sel
+}
+
+func CreateCssSelector(expression string) (HtmlContentSelector, error) {
+	if len(expression) == 0 {
+		return nil, fmt.Errorf("content: invalid empty css selector provided")
+	}
+
+	sel, err := cascadia.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("content: failed to compile the css selector: %w", err)
+	}
+
+	return &cssSelector{
+		expression: expression,
+		sel:        sel,
+	}, nil
+}
+
+func (s *cssSelector) QueryFirst(node *html.Node) *html.Node {
+	return cascadia.Query(node, s.sel)
+}
+
+func (s *cssSelector) QueryAll(node *html.Node) []*html.Node {
+	return cascadia.QueryAll(node, s.sel)
+}
+
+func (s *cssSelector) String() string {
+	return s.expression
+}
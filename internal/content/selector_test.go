@@ -0,0 +1,54 @@
+package content
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const selectorTestHtml = `<html><body>
+<table id="clients">
+<tr><td data-kind="mac">AA:BB:CC:DD:EE:01</td><td>first</td></tr>
+<tr><td data-kind="mac">AA:BB:CC:DD:EE:02</td><td>second</td></tr>
+</table>
+</body></html>`
+
+func TestXpathAndCssSelectorsShouldMatchTheSameElements(t *testing.T) {
+	html, err := CreateHtmlContent(selectorTestHtml)
+	assert.Nil(t, err)
+
+	cases := []struct {
+		xpath    string
+		css      string
+		expected string
+	}{
+		{xpath: "//table[@id='clients']/tbody/tr[2]/td[2]", css: "#clients tr:nth-child(2) td:nth-child(2)", expected: "second"},
+		{xpath: "//td[@data-kind='mac']", css: "td[data-kind=mac]", expected: "AA:BB:CC:DD:EE:01"},
+	}
+
+	for _, c := range cases {
+		xpathSelector, err := CreateXpathSelector(c.xpath)
+		assert.Nil(t, err)
+
+		cssSelector, err := CreateCssSelector(c.css)
+		assert.Nil(t, err)
+
+		for _, selector := range []HtmlContentSelector{xpathSelector, cssSelector} {
+			element, found, err := html.GetFirstElement(selector)
+			assert.Nil(t, err)
+			assert.True(t, found)
+
+			actual, err := element.GetContentString(Text, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, c.expected, actual)
+		}
+	}
+}
+
+func TestSelectorsShouldNotCreateFromInvalidExpressions(t *testing.T) {
+	_, err := CreateXpathSelector("//td[")
+	assert.NotNil(t, err)
+
+	_, err = CreateCssSelector("td[data-kind")
+	assert.NotNil(t, err)
+}
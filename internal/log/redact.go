@@ -0,0 +1,107 @@
+package log
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Krzysztofz01/apikit/internal/utils"
+)
+
+const redactedPlaceholder string = "[REDACTED]"
+
+// Replace all occurrences of the secrets in the message with a placeholder
+func Redact(message string, secrets []string) string {
+	return redactSorted(message, sortSecrets(slices.Clone(secrets)))
+}
+
+// Sort the secrets from the longest to the shortest, so a secret being a part of another secret does not leave the rest
+// of the longer secret exposed
+func sortSecrets(secrets []string) []string {
+	slices.SortStableFunc(secrets, func(a, b string) int {
+		return len(b) - len(a)
+	})
+
+	return secrets
+}
+
+func redactSorted(message string, secrets []string) string {
+	for _, secret := range secrets {
+		if len(secret) == 0 {
+			continue
+		}
+
+		message = strings.ReplaceAll(message, secret, redactedPlaceholder)
+	}
+
+	return message
+}
+
+type RedactingLogger interface {
+	Logger
+
+	// Add secrets to redact. Secrets are never removed as they can still be part of messages of in-flight operations
+	AddSecrets(secrets []string)
+}
+
+type redactingLogger struct {
+	logger    Logger
+	secrets   []string
+	secretSet utils.Set[string]
+	mu        sync.RWMutex
+}
+
+// Create a logger that redacts the provided secrets from the formatted messages before passing them to the underlying logger
+func CreateRedactingLogger(secrets []string, l Logger) RedactingLogger {
+	logger := &redactingLogger{
+		logger:    l,
+		secrets:   make([]string, 0, len(secrets)),
+		secretSet: utils.NewEmptySet[string](),
+		mu:        sync.RWMutex{},
+	}
+
+	logger.AddSecrets(secrets)
+	return logger
+}
+
+// NOTE: The secrets are added on every reload, so the already known secrets are skipped in order to keep the list bounded
+func (l *redactingLogger) AddSecrets(secrets []string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	added := false
+	for _, secret := range secrets {
+		if len(secret) != 0 && l.secretSet.Add(secret) {
+			l.secrets = append(l.secrets, secret)
+			added = true
+		}
+	}
+
+	if added {
+		l.secrets = sortSecrets(l.secrets)
+	}
+}
+
+func (l *redactingLogger) redact(format string, args ...interface{}) string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return redactSorted(fmt.Sprintf(format, args...), l.secrets)
+}
+
+func (l *redactingLogger) Debugf(prefix, format string, args ...interface{}) {
+	l.logger.Debugf(prefix, "%s", l.redact(format, args...))
+}
+
+func (l *redactingLogger) Errorf(prefix, format string, args ...interface{}) {
+	l.logger.Errorf(prefix, "%s", l.redact(format, args...))
+}
+
+func (l *redactingLogger) Infof(prefix, format string, args ...interface{}) {
+	l.logger.Infof(prefix, "%s", l.redact(format, args...))
+}
+
+func (l *redactingLogger) Warnf(prefix, format string, args ...interface{}) {
+	l.logger.Warnf(prefix, "%s", l.redact(format, args...))
+}
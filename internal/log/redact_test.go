@@ -0,0 +1,51 @@
+package log
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedactShouldReplaceLongerSecretsFirst(t *testing.T) {
+	cases := []struct {
+		message  string
+		secrets  []string
+		expected string
+	}{
+		{message: "token abc123 and abc", secrets: []string{"abc", "abc123"}, expected: "token [REDACTED] and [REDACTED]"},
+		{message: "user admin:hunter2", secrets: []string{"hunter2", "admin:hunter2"}, expected: "user [REDACTED]"},
+		{message: "no secrets", secrets: []string{"", "hunter2"}, expected: "no secrets"},
+	}
+
+	for _, c := range cases {
+		actual := Redact(c.message, c.secrets)
+
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debugf(prefix, format string, args ...interface{}) {}
+func (l *recordingLogger) Infof(prefix, format string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(format, args...))
+}
+func (l *recordingLogger) Warnf(prefix, format string, args ...interface{})  {}
+func (l *recordingLogger) Errorf(prefix, format string, args ...interface{}) {}
+
+func TestRedactingLoggerShouldNotDuplicateAddedSecrets(t *testing.T) {
+	recorder := &recordingLogger{}
+	logger := CreateRedactingLogger([]string{"hunter2"}, recorder)
+
+	for i := 0; i < 10; i++ {
+		logger.AddSecrets([]string{"hunter2", "s3cr3t", "hunter2-long", ""})
+	}
+
+	logger.Infof("Test", "password %s, token %s, key %s", "hunter2", "s3cr3t", "hunter2-long")
+
+	assert.Len(t, logger.(*redactingLogger).secrets, 3)
+	assert.Equal(t, []string{"password [REDACTED], token [REDACTED], key [REDACTED]"}, recorder.messages)
+}
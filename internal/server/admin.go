@@ -0,0 +1,225 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/Krzysztofz01/apikit/internal/log"
+	"github.com/labstack/echo/v4"
+)
+
+// Header carrying the secret of one of the api keys of the admin api key pool
+const AdminApiKeyHeader string = "X-Api-Key"
+
+// Collection of the config items managed via the admin api, where the source values are nested under the sources
+type adminCollection struct {
+	path      string
+	itemParam string
+	kind      config.ConfigurationItemKind
+}
+
+var adminCollections = []*adminCollection{
+	{path: "/sources", itemParam: "source", kind: config.SourceItem},
+	{path: "/sources/:source/values", itemParam: "value", kind: config.SourceValueItem},
+	{path: "/endpoints", itemParam: "endpoint", kind: config.EndpointItem},
+	{path: "/api-keys", itemParam: "apikey", kind: config.ApiKeyItem},
+}
+
+func (s *apiKitServer) registerAdminEndpoints() {
+	for _, collection := range adminCollections {
+		collectionPath := config.AdminApiPathPrefix + collection.path
+		itemPath := collectionPath + "/:" + collection.itemParam
+
+		s.server.GET(collectionPath, s.adminListHandle(collection), s.adminAuthMiddleware)
+		s.server.POST(collectionPath, s.adminCreateHandle(collection), s.adminAuthMiddleware)
+		s.server.GET(itemPath, s.adminGetHandle(collection), s.adminAuthMiddleware)
+		s.server.PUT(itemPath, s.adminUpdateHandle(collection), s.adminAuthMiddleware)
+		s.server.DELETE(itemPath, s.adminDeleteHandle(collection), s.adminAuthMiddleware)
+	}
+}
+
+// NOTE: The admin routes are always registered, as the admin api can be enabled or disabled on reload. The GET requests
+// are passed to the server endpoints when the admin api is disabled, as the admin paths are then free to use
+func (s *apiKitServer) adminAuthMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		cfg := s.state.Load().cfg
+		if s.store == nil || !cfg.IsAdminEnabled() {
+			if c.Request().Method == http.MethodGet {
+				return s.GetRequestHandle(c)
+			}
+
+			return c.NoContent(http.StatusNotFound)
+		}
+
+		if !isAdminApiKey(cfg, c.Request().Header.Get(AdminApiKeyHeader)) {
+			s.logger.Warnf("HTTP 401 %s %s unauthorized admin api request", c.Request().Method, c.Request().URL.Path)
+			return c.NoContent(http.StatusUnauthorized)
+		}
+
+		return next(c)
+	}
+}
+
+func isAdminApiKey(c *config.ApiKitServerConfiguration, secret string) bool {
+	if len(secret) == 0 {
+		return false
+	}
+
+	// NOTE: All api keys of the pool are compared in order to not leak the matching key via the response time
+	authorized := false
+	for _, apiKey := range c.ApiKeys {
+		if !slices.Contains(c.Admin.RequiredApiKeyPool, apiKey.Name) {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(apiKey.Secret), []byte(secret)) == 1 {
+			authorized = true
+		}
+	}
+
+	return authorized
+}
+
+func (s *apiKitServer) adminListHandle(collection *adminCollection) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if items, err := s.store.List(collection.kind, c.Param("source")); err != nil {
+			return s.adminErrorResponse(c, err)
+		} else {
+			return c.JSON(http.StatusOK, items)
+		}
+	}
+}
+
+func (s *apiKitServer) adminGetHandle(collection *adminCollection) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if item, err := s.store.Get(collection.kind, c.Param("source"), c.Param(collection.itemParam)); err != nil {
+			return s.adminErrorResponse(c, err)
+		} else {
+			return c.JSON(http.StatusOK, item)
+		}
+	}
+}
+
+func (s *apiKitServer) adminCreateHandle(collection *adminCollection) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		item := make(map[string]interface{})
+		if err := json.NewDecoder(c.Request().Body).Decode(&item); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "invalid json request body"})
+		}
+
+		return s.adminChange(c, collection, adminItemName(item), http.StatusCreated, func() (*config.ApiKitServerConfiguration, func() error, error) {
+			return s.store.Create(collection.kind, c.Param("source"), item)
+		})
+	}
+}
+
+func (s *apiKitServer) adminUpdateHandle(collection *adminCollection) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		item := make(map[string]interface{})
+		if err := json.NewDecoder(c.Request().Body).Decode(&item); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": "invalid json request body"})
+		}
+
+		return s.adminChange(c, collection, adminItemName(item), http.StatusOK, func() (*config.ApiKitServerConfiguration, func() error, error) {
+			return s.store.Update(collection.kind, c.Param("source"), c.Param(collection.itemParam), item)
+		})
+	}
+}
+
+func (s *apiKitServer) adminDeleteHandle(collection *adminCollection) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return s.adminChange(c, collection, c.Param(collection.itemParam), http.StatusNoContent, func() (*config.ApiKitServerConfiguration, func() error, error) {
+			return s.store.Delete(collection.kind, c.Param("source"), c.Param(collection.itemParam))
+		})
+	}
+}
+
+// Apply the configuration change to the server and persist it in the config file. The changes are serialized with all
+// other reloads, so the config file always reflects the served configuration. The previous configuration is restored
+// if persisting fails
+func (s *apiKitServer) adminChange(
+	c echo.Context,
+	collection *adminCollection,
+	name string,
+	status int,
+	change func() (*config.ApiKitServerConfiguration, func() error, error),
+) error {
+	t := time.Now()
+	path := c.Request().URL.Path
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	cfg, persist, err := change()
+	if err != nil {
+		return s.adminErrorResponse(c, err)
+	}
+
+	// NOTE: The secrets of the changed configuration are redacted before the configuration is logged by the reload
+	if logger, ok := s.baseLogger.(log.RedactingLogger); ok {
+		logger.AddSecrets(cfg.Secrets)
+	}
+
+	previous := s.state.Load().cfg
+	if err := s.reload(cfg); err != nil {
+		s.logger.Errorf("HTTP 500 %s %s in %dms failed with %s", c.Request().Method, path, time.Since(t).Milliseconds(), err.Error())
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	if err := persist(); err != nil {
+		s.logger.Errorf("HTTP 500 %s %s in %dms failed with %s", c.Request().Method, path, time.Since(t).Milliseconds(), err.Error())
+
+		if err := s.reload(previous); err != nil {
+			s.logger.Errorf("Failed to restore the previous configuration: %s", err.Error())
+		}
+
+		return c.NoContent(http.StatusInternalServerError)
+	}
+
+	s.logger.Infof("HTTP %d %s %s in %dms", status, c.Request().Method, path, time.Since(t).Milliseconds())
+
+	if status == http.StatusNoContent {
+		return c.NoContent(status)
+	}
+
+	// NOTE: The item is read back from the config file, so the response contains the persisted and redacted item
+	if item, err := s.store.Get(collection.kind, c.Param("source"), name); err != nil {
+		return s.adminErrorResponse(c, err)
+	} else {
+		return c.JSON(status, item)
+	}
+}
+
+// Find the name of the item in the request body. The keys are matched case-insensitively like the config file keys
+func adminItemName(item map[string]interface{}) string {
+	for key, value := range item {
+		if name, ok := value.(string); ok && strings.EqualFold(key, "name") {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func (s *apiKitServer) adminErrorResponse(c echo.Context, err error) error {
+	var validationErrs config.ValidationErrors
+	switch {
+	case errors.Is(err, config.ErrConfigurationItemNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+	case errors.Is(err, config.ErrConfigurationItemExists):
+		return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error()})
+	case errors.As(err, &validationErrs):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": "invalid configuration change", "errors": validationErrs})
+	case errors.Is(err, config.ErrInvalidConfigurationChange):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{"error": err.Error()})
+	default:
+		s.logger.Errorf("HTTP 500 %s %s failed with %s", c.Request().Method, c.Request().URL.Path, err.Error())
+		return c.NoContent(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,164 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+// Configuration store applying every change with a failing persist function
+type failingPersistStore struct {
+	config.ConfigurationStore
+	cfg *config.ApiKitServerConfiguration
+}
+
+func (s *failingPersistStore) Create(_ config.ConfigurationItemKind, _ string, _ map[string]interface{}) (*config.ApiKitServerConfiguration, func() error, error) {
+	return s.cfg, func() error { return errors.New("disk full") }, nil
+}
+
+const adminTestConfiguration string = `{"host": "localhost:8080", "version": 2, "admin": {"enabled": %s, "required-api-key-name-pool": ["admin"]}, "api-keys": [{"name": "admin", "secret": "admin-secret"}, {"name": "reader", "secret": "reader-secret"}], "general": {"sources": [{"name": "router", "url": "http://localhost:1", "values": [{"name": "uptime", "xpath": "//td", "extraction-strategy": "first", "type": {"name": "int"}}]}%s], "endpoints": [{"name": "router", "values": [{"name": "uptime", "source-name": "router", "source-value-name": "uptime"}]}]}, "endpoints": [{"name": "router", "path": "/router", "required-api-key-name-pool": ["reader"]}]}`
+
+func loadAdminTestConfiguration(t *testing.T, enabled bool, extraSources string) (*config.ApiKitServerConfiguration, string) {
+	content := fmt.Sprintf(adminTestConfiguration, strconv.FormatBool(enabled), extraSources)
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+
+	c, err := config.LoadServerConfigurationFromFile(path)
+	assert.Nil(t, err)
+
+	return c, path
+}
+
+func createAdminTestServer(t *testing.T, enabled bool) (*apiKitServer, string) {
+	c, path := loadAdminTestConfiguration(t, enabled, "")
+
+	store, err := config.CreateConfigurationStore(path)
+	assert.Nil(t, err)
+
+	s, err := CreateApiKitServer(http.DefaultClient, c, store, testLogger{})
+	assert.Nil(t, err)
+
+	return s.(*apiKitServer), path
+}
+
+func serveAdminTestRequest(s *apiKitServer, method, path, apiKey, body string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	if len(apiKey) != 0 {
+		request.Header.Set(AdminApiKeyHeader, apiKey)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.server.ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func TestAdminApiShouldRejectUnauthorizedRequests(t *testing.T) {
+	s, _ := createAdminTestServer(t, true)
+
+	cases := []string{"", "invalid-secret", "reader-secret"}
+
+	for _, c := range cases {
+		recorder := serveAdminTestRequest(s, http.MethodGet, "/admin/sources", c, "")
+
+		assert.Equal(t, http.StatusUnauthorized, recorder.Code)
+	}
+
+	recorder := serveAdminTestRequest(s, http.MethodGet, "/admin/sources", "admin-secret", "")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestAdminApiShouldNotBeServedWhenDisabled(t *testing.T) {
+	s, _ := createAdminTestServer(t, false)
+
+	cases := []string{http.MethodGet, http.MethodPost}
+
+	for _, c := range cases {
+		recorder := serveAdminTestRequest(s, c, "/admin/sources", "admin-secret", `{"name": "switch"}`)
+
+		assert.Equal(t, http.StatusNotFound, recorder.Code)
+	}
+}
+
+func TestAdminApiShouldMapChangeErrorsToStatusCodes(t *testing.T) {
+	s, path := createAdminTestServer(t, true)
+
+	cases := []struct {
+		method   string
+		path     string
+		body     string
+		expected int
+	}{
+		{method: http.MethodPost, path: "/admin/sources", body: `{"name": "router"}`, expected: http.StatusConflict},
+		{method: http.MethodPost, path: "/admin/sources", body: `{"name": "switch"`, expected: http.StatusBadRequest},
+		{method: http.MethodPost, path: "/admin/sources/router/values", body: `{"name": "load", "xpath": "//td[", "extraction-strategy": "first", "type": {"name": "int"}}`, expected: http.StatusUnprocessableEntity},
+		{method: http.MethodDelete, path: "/admin/api-keys/admin", expected: http.StatusUnprocessableEntity},
+		{method: http.MethodGet, path: "/admin/endpoints/switch", expected: http.StatusNotFound},
+		{method: http.MethodPut, path: "/admin/sources/switch", body: `{"name": "switch"}`, expected: http.StatusNotFound},
+	}
+
+	for _, c := range cases {
+		recorder := serveAdminTestRequest(s, c.method, c.path, "admin-secret", c.body)
+
+		assert.Equal(t, c.expected, recorder.Code, "%s %s", c.method, c.path)
+	}
+
+	recorder := serveAdminTestRequest(s, http.MethodPost, "/admin/sources/router/values", "admin-secret", `{"name": "load", "xpath": "//td[", "extraction-strategy": "first", "type": {"name": "int"}}`)
+	assert.Contains(t, recorder.Body.String(), `"path":"general.sources[0].values[1].xpath"`)
+
+	recorder = serveAdminTestRequest(s, http.MethodPost, "/admin/sources", "admin-secret", `{"name": "switch"}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+	assert.Len(t, s.state.Load().cfg.ApiKit.Sources, 2)
+
+	content, err := os.ReadFile(path)
+	assert.Nil(t, err)
+	assert.Contains(t, string(content), `"switch"`)
+}
+
+func TestAdminApiShouldRestorePreviousConfigurationWhenPersistFails(t *testing.T) {
+	s, _ := createAdminTestServer(t, true)
+	previous := s.state.Load().cfg
+
+	changed, _ := loadAdminTestConfiguration(t, true, `, {"name": "switch"}`)
+	s.store = &failingPersistStore{ConfigurationStore: s.store, cfg: changed}
+
+	recorder := serveAdminTestRequest(s, http.MethodPost, "/admin/sources", "admin-secret", `{"name": "switch"}`)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Same(t, previous, s.state.Load().cfg)
+}
+
+func TestAdminApiChangesShouldNotBeReloadedFromTheWrittenFile(t *testing.T) {
+	s, path := createAdminTestServer(t, true)
+
+	recorder := serveAdminTestRequest(s, http.MethodPost, "/admin/sources/router/values", "admin-secret", `{"name": "load", "xpath": "//td", "extraction-strategy": "first", "type": {"name": "float"}, "required": false, "default": 0.5}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code)
+
+	served := s.state.Load().cfg
+
+	_, reloaded, err := s.ReloadWith(func() (*config.ApiKitServerConfiguration, error) {
+		return config.LoadServerConfigurationFromFile(path)
+	})
+
+	assert.Nil(t, err)
+	assert.False(t, reloaded)
+	assert.Same(t, served, s.state.Load().cfg)
+
+	changed, _ := loadAdminTestConfiguration(t, true, `, {"name": "switch"}`)
+	_, reloaded, err = s.ReloadWith(func() (*config.ApiKitServerConfiguration, error) {
+		return changed, nil
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, reloaded)
+	assert.Same(t, changed, s.state.Load().cfg)
+}
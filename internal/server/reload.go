@@ -0,0 +1,72 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+)
+
+// Create a human readable summary of the added, removed and changed sources, endpoints and server paths
+func describeConfigurationChanges(previous, current *config.ApiKitServerConfiguration) string {
+	previousSources := make(map[string]interface{}, len(previous.ApiKit.Sources))
+	for _, source := range previous.ApiKit.Sources {
+		previousSources[source.Name] = source
+	}
+
+	currentSources := make(map[string]interface{}, len(current.ApiKit.Sources))
+	for _, source := range current.ApiKit.Sources {
+		currentSources[source.Name] = source
+	}
+
+	previousEndpoints := make(map[string]interface{}, len(previous.ApiKit.Endpoints))
+	for _, endpoint := range previous.ApiKit.Endpoints {
+		previousEndpoints[endpoint.Name] = endpoint
+	}
+
+	currentEndpoints := make(map[string]interface{}, len(current.ApiKit.Endpoints))
+	for _, endpoint := range current.ApiKit.Endpoints {
+		currentEndpoints[endpoint.Name] = endpoint
+	}
+
+	previousPaths := make(map[string]interface{}, len(previous.Endpoints))
+	for _, endpoint := range previous.Endpoints {
+		previousPaths[endpoint.Path] = endpoint
+	}
+
+	currentPaths := make(map[string]interface{}, len(current.Endpoints))
+	for _, endpoint := range current.Endpoints {
+		currentPaths[endpoint.Path] = endpoint
+	}
+
+	return strings.Join([]string{
+		describeChanges("sources", previousSources, currentSources),
+		describeChanges("endpoints", previousEndpoints, currentEndpoints),
+		describeChanges("paths", previousPaths, currentPaths),
+	}, ", ")
+}
+
+func describeChanges(name string, previous, current map[string]interface{}) string {
+	var added, removed, changed []string
+	for key, currentValue := range current {
+		if previousValue, ok := previous[key]; !ok {
+			added = append(added, key)
+		} else if !reflect.DeepEqual(previousValue, currentValue) {
+			changed = append(changed, key)
+		}
+	}
+
+	for key := range previous {
+		if _, ok := current[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+
+	slices.Sort(added)
+	slices.Sort(removed)
+	slices.Sort(changed)
+
+	return fmt.Sprintf("%s added %v removed %v changed %v", name, added, removed, changed)
+}
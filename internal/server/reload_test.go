@@ -0,0 +1,30 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeConfigurationChangesShouldReportChangedItems(t *testing.T) {
+	previous := &config.ApiKitServerConfiguration{
+		ApiKit: &config.ApiKitConfiguration{
+			Sources:   []*config.SourceConfiguration{{Name: "router", Url: "http://192.168.0.1"}, {Name: "switch"}},
+			Endpoints: []*config.EndpointConfiguration{{Name: "router"}},
+		},
+		Endpoints: []*config.ApiKitServerEndpointConfiguration{{EndpointName: "router", Path: "/router"}},
+	}
+
+	current := &config.ApiKitServerConfiguration{
+		ApiKit: &config.ApiKitConfiguration{
+			Sources:   []*config.SourceConfiguration{{Name: "router", Url: "http://192.168.0.2"}, {Name: "modem"}},
+			Endpoints: []*config.EndpointConfiguration{{Name: "router"}},
+		},
+		Endpoints: []*config.ApiKitServerEndpointConfiguration{{EndpointName: "router", Path: "/status"}},
+	}
+
+	actual := describeConfigurationChanges(previous, current)
+
+	assert.Equal(t, "sources added [modem] removed [switch] changed [router], endpoints added [] removed [] changed [], paths added [/status] removed [/router] changed []", actual)
+}
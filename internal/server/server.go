@@ -2,13 +2,18 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Krzysztofz01/apikit/internal/client"
 	"github.com/Krzysztofz01/apikit/internal/config"
 	"github.com/Krzysztofz01/apikit/internal/log"
+	"github.com/Krzysztofz01/apikit/internal/source"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
@@ -16,18 +21,42 @@ import (
 type ApiKitServer interface {
 	Start() error
 	Shutdown(ctx context.Context) error
+
+	// Validate the configuration and atomically replace the client, changed sources and routes. The unchanged sources are
+	// kept with their caches. The current configuration is kept if the validation or the setup fails. The host can not be
+	// changed without a restart
+	Reload(c *config.ApiKitServerConfiguration) error
+
+	// Load the configuration with the given function and reload it, unless it equals the served configuration (e.g. the
+	// config file written by the admin api). The configuration is loaded under the same lock as the admin api changes,
+	// so a config file is never loaded in the middle of an admin api change. The loaded configuration is returned
+	// alongside the information whether it was reloaded
+	ReloadWith(load func() (*config.ApiKitServerConfiguration, error)) (*config.ApiKitServerConfiguration, bool, error)
 }
 
 type apiKitServer struct {
-	server                 *echo.Echo
+	server     *echo.Echo
+	state      atomic.Pointer[apiKitServerState]
+	httpClient *http.Client
+	baseLogger log.Logger
+	logger     log.Loggerp
+	isStarted  bool
+	reloadMu   sync.Mutex
+	store      config.ConfigurationStore
+	cfg        *config.ApiKitServerConfiguration
+}
+
+// Configuration dependent part of the server that is replaced as a whole on reload
+type apiKitServerState struct {
 	apiKitClient           client.ApiKitClient
+	router                 *echo.Echo
 	endpointNamePathLookup map[string]string
-	logger                 log.Loggerp
-	isStarted              bool
+	endpointMetadata       map[string]bool
 	cfg                    *config.ApiKitServerConfiguration
 }
 
-func CreateApiKitServer(h *http.Client, c *config.ApiKitServerConfiguration, l log.Logger) (ApiKitServer, error) {
+// Create the server. The configuration store is used by the admin api and can be nil, which disables the admin api
+func CreateApiKitServer(h *http.Client, c *config.ApiKitServerConfiguration, cs config.ConfigurationStore, l log.Logger) (ApiKitServer, error) {
 	if h == nil {
 		return nil, fmt.Errorf("server: invalid nil reference http client provided")
 	}
@@ -42,42 +71,142 @@ func CreateApiKitServer(h *http.Client, c *config.ApiKitServerConfiguration, l l
 
 	logger := log.CreatePrefixedLogger("Server", l)
 
+	server := echo.New()
+
+	server.Use(middleware.Recover())
+	server.Use(middleware.CORS())
+
+	apiKitServer := &apiKitServer{
+		server:     server,
+		httpClient: h,
+		baseLogger: l,
+		logger:     logger,
+		isStarted:  false,
+		reloadMu:   sync.Mutex{},
+		store:      cs,
+		cfg:        c,
+	}
+
 	logger.Infof("Apikit client setup started")
 
-	apiKitClient, err := client.CreateApiKitClient(h, c.ApiKit, l)
+	state, err := createApiKitServerState(h, c, l, nil, apiKitServer.endpointRequestHandle)
 	if err != nil {
-		return nil, fmt.Errorf("server: failed to create apikit client: %w", err)
+		return nil, fmt.Errorf("server: failed to create the server state: %w", err)
 	}
 
 	logger.Infof("Apikit client setup finished")
-	logger.Infof("Apikit server setup started")
 
-	server := echo.New()
+	apiKitServer.state.Store(state)
 
-	server.Use(middleware.Recover())
-	server.Use(middleware.CORS())
+	if err := apiKitServer.RegisterEndpoints(); err != nil {
+		return nil, fmt.Errorf("server: failed to register endpoints: %w", err)
+	}
+
+	return apiKitServer, nil
+}
+
+// Create the client and the router of the configuration. The router routes are handled with the given handler, which
+// is provided with the created state. The unchanged sources of the previous state are reused if it is provided
+func createApiKitServerState(h *http.Client, c *config.ApiKitServerConfiguration, l log.Logger, previous *apiKitServerState, handle func(state *apiKitServerState, c echo.Context) error) (*apiKitServerState, error) {
+	var (
+		apiKitClient client.ApiKitClient
+		err          error
+	)
+
+	if previous != nil {
+		apiKitClient, err = client.CreateReloadedApiKitClient(previous.apiKitClient, h, c.ApiKit, l)
+	} else {
+		apiKitClient, err = client.CreateApiKitClient(h, c.ApiKit, l)
+	}
 
-	logger.Infof("Apikit server setup finished")
+	if err != nil {
+		return nil, fmt.Errorf("server: failed to create apikit client: %w", err)
+	}
 
 	endpointNamePathLookup := make(map[string]string, len(c.Endpoints))
 	for _, endpoint := range c.Endpoints {
 		endpointNamePathLookup[endpoint.Path] = endpoint.EndpointName
 	}
 
-	apiKitServer := &apiKitServer{
-		server:                 server,
+	endpointMetadata := make(map[string]bool, len(c.ApiKit.Endpoints))
+	for _, endpoint := range c.ApiKit.Endpoints {
+		endpointMetadata[endpoint.Name] = endpoint.Metadata
+	}
+
+	state := &apiKitServerState{
 		apiKitClient:           apiKitClient,
+		router:                 echo.New(),
 		endpointNamePathLookup: endpointNamePathLookup,
-		logger:                 logger,
-		isStarted:              false,
+		endpointMetadata:       endpointMetadata,
 		cfg:                    c,
 	}
 
-	if err := apiKitServer.RegisterEndpoints(); err != nil {
-		return nil, fmt.Errorf("server: failed to register endpoints: %w", err)
+	// NOTE: A router is created per configuration, so the endpoint paths keep the echo routing semantics (e.g. path
+	// parameters) while the routes can still change on reload
+	for _, endpoint := range c.Endpoints {
+		state.router.GET(endpoint.Path, func(ctx echo.Context) error {
+			return handle(state, ctx)
+		})
 	}
 
-	return apiKitServer, nil
+	return state, nil
+}
+
+func (s *apiKitServer) Reload(c *config.ApiKitServerConfiguration) error {
+	if c == nil {
+		return fmt.Errorf("server: invalid nil reference configuration provided")
+	}
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	return s.reload(c)
+}
+
+func (s *apiKitServer) ReloadWith(load func() (*config.ApiKitServerConfiguration, error)) (*config.ApiKitServerConfiguration, bool, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	c, err := load()
+	if err != nil {
+		return nil, false, fmt.Errorf("server: failed to load the reloaded configuration: %w", err)
+	}
+
+	if c == nil {
+		return nil, false, fmt.Errorf("server: invalid nil reference configuration provided")
+	}
+
+	if c.Equal(s.state.Load().cfg) {
+		return c, false, nil
+	}
+
+	if err := s.reload(c); err != nil {
+		return nil, false, err
+	}
+
+	return c, true, nil
+}
+
+// Replace the server state with the state of the configuration. The caller must hold the reload lock
+func (s *apiKitServer) reload(c *config.ApiKitServerConfiguration) error {
+	if valid, msg := config.ValidateServer(c); !valid {
+		return fmt.Errorf("server: the reloaded configuration is not valid: %s", msg)
+	}
+
+	state, err := createApiKitServerState(s.httpClient, c, s.baseLogger, s.state.Load(), s.endpointRequestHandle)
+	if err != nil {
+		return fmt.Errorf("server: failed to create the reloaded server state: %w", err)
+	}
+
+	// NOTE: In-flight requests finish with the state they started with
+	previousState := s.state.Swap(state)
+
+	if c.Host != s.cfg.Host {
+		s.logger.Warnf("Host change from %s to %s requires a restart to take effect", s.cfg.Host, c.Host)
+	}
+
+	s.logger.Infof("Configuration reloaded: %s", describeConfigurationChanges(previousState.cfg, c))
+	return nil
 }
 
 func (s *apiKitServer) Shutdown(ctx context.Context) error {
@@ -113,28 +242,69 @@ func (s *apiKitServer) RegisterEndpoints() error {
 		return fmt.Errorf("server: failed to register endpoints with the server running")
 	}
 
-	for _, endpoint := range s.cfg.Endpoints {
-		s.server.GET(endpoint.Path, s.GetRequestHandle)
-	}
+	// NOTE: A single wildcard route dispatches the requests to the router of the current state in order to allow route
+	// changes on reload
+	s.server.GET("/*", s.GetRequestHandle)
+
+	s.registerAdminEndpoints()
 
 	return nil
 }
 
 func (s *apiKitServer) GetRequestHandle(c echo.Context) error {
+	s.state.Load().router.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// NOTE: In-flight requests finish with the state of the router that handles them
+func (s *apiKitServer) endpointRequestHandle(state *apiKitServerState, c echo.Context) error {
 	t := time.Now()
 
-	endpointName, ok := s.endpointNamePathLookup[c.Path()]
+	path := c.Request().URL.Path
+
+	endpointName, ok := state.endpointNamePathLookup[c.Path()]
 	if !ok {
 		return c.NoContent(http.StatusNotFound)
 	}
 
-	result, err := s.apiKitClient.Get(endpointName)
+	// NOTE: The metadata envelope can be enabled per endpoint and overridden per request via the meta query parameter
+	metadata := state.endpointMetadata[endpointName]
+	if meta := c.QueryParam("meta"); len(meta) != 0 {
+		if value, err := strconv.ParseBool(meta); err != nil {
+			return c.NoContent(http.StatusBadRequest)
+		} else {
+			metadata = value
+		}
+	}
+
+	var (
+		result map[string]interface{}
+		err    error
+	)
+
+	if metadata {
+		result, err = state.apiKitClient.GetWithMetadata(endpointName)
+	} else {
+		result, err = state.apiKitClient.Get(endpointName)
+	}
+
 	if err != nil {
+		var violation *source.ConstraintViolationError
+		if errors.As(err, &violation) {
+			s.logger.Warnf("HTTP 502 %s in %dms failed with %s", path, time.Since(t).Milliseconds(), err.Error())
+			return c.JSON(http.StatusBadGateway, map[string]interface{}{
+				"error":      "constraint violation",
+				"source":     violation.SourceName,
+				"value":      violation.ValueName,
+				"constraint": violation.Constraint,
+			})
+		}
+
 		// TODO: Better error handling that will be able to tell the difference between 4xx and 5xx
-		s.logger.Errorf("HTTP 500 %s in %dms failed with %s", c.Path(), time.Since(t).Milliseconds(), err.Error())
+		s.logger.Errorf("HTTP 500 %s in %dms failed with %s", path, time.Since(t).Milliseconds(), err.Error())
 		return c.NoContent(http.StatusInternalServerError)
 	}
 
-	s.logger.Infof("HTTP 200 %s in %dms", c.Path(), time.Since(t).Milliseconds())
+	s.logger.Infof("HTTP 200 %s in %dms", path, time.Since(t).Milliseconds())
 	return c.JSON(http.StatusOK, result)
 }
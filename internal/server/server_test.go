@@ -0,0 +1,131 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogger struct{}
+
+func (testLogger) Debugf(prefix, format string, args ...interface{}) {}
+func (testLogger) Infof(prefix, format string, args ...interface{})  {}
+func (testLogger) Warnf(prefix, format string, args ...interface{})  {}
+func (testLogger) Errorf(prefix, format string, args ...interface{}) {}
+
+const serverTestConfiguration string = `{"host": "localhost:8080", "version": 2, "general": {"sources": [{"name": "router", "url": "%s", "timeout-seconds": 5, "caching-enabled": true, "caching-life-time-seconds": 60, "values": [{"name": "uptime", "xpath": "//td", "extraction-strategy": "first", "type": {"name": "int"}}]}], "endpoints": [{"name": "router", "values": [{"name": "uptime", "source-name": "router", "source-value-name": "uptime"}]}]}, "endpoints": [%s]}`
+
+func loadServerTestConfiguration(t *testing.T, sourceUrl string, paths ...string) *config.ApiKitServerConfiguration {
+	endpoints := make([]string, 0, len(paths))
+	for _, path := range paths {
+		endpoints = append(endpoints, fmt.Sprintf(`{"name": "router", "path": "%s", "required-api-key-name-pool": []}`, path))
+	}
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	assert.Nil(t, os.WriteFile(path, []byte(fmt.Sprintf(serverTestConfiguration, sourceUrl, strings.Join(endpoints, ", "))), 0o600))
+
+	c, err := config.LoadServerConfigurationFromFile(path)
+	assert.Nil(t, err)
+
+	return c
+}
+
+func createServerTestSource(t *testing.T) string {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`<table><tr><td>120</td></tr></table>`))
+	}))
+
+	t.Cleanup(source.Close)
+	return source.URL
+}
+
+func serveServerTestRequest(s *apiKitServer, path string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	s.server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	return recorder
+}
+
+func TestServerShouldRouteEndpointPaths(t *testing.T) {
+	sourceUrl := createServerTestSource(t)
+
+	s, err := CreateApiKitServer(http.DefaultClient, loadServerTestConfiguration(t, sourceUrl, "/router", "/devices/:device/status"), nil, testLogger{})
+	assert.Nil(t, err)
+
+	cases := []struct {
+		path     string
+		expected int
+	}{
+		{path: "/router", expected: http.StatusOK},
+		{path: "/devices/router-01/status", expected: http.StatusOK},
+		{path: "/devices/router-01", expected: http.StatusNotFound},
+		{path: "/switch", expected: http.StatusNotFound},
+	}
+
+	for _, c := range cases {
+		recorder := serveServerTestRequest(s.(*apiKitServer), c.path)
+
+		assert.Equal(t, c.expected, recorder.Code, c.path)
+	}
+
+	recorder := serveServerTestRequest(s.(*apiKitServer), "/router")
+	assert.JSONEq(t, `{"uptime": 120}`, recorder.Body.String())
+}
+
+func TestServerShouldChangeRoutesOnReload(t *testing.T) {
+	sourceUrl := createServerTestSource(t)
+
+	s, err := CreateApiKitServer(http.DefaultClient, loadServerTestConfiguration(t, sourceUrl, "/router"), nil, testLogger{})
+	assert.Nil(t, err)
+
+	assert.Nil(t, s.Reload(loadServerTestConfiguration(t, sourceUrl, "/status")))
+	assert.Equal(t, http.StatusNotFound, serveServerTestRequest(s.(*apiKitServer), "/router").Code)
+	assert.Equal(t, http.StatusOK, serveServerTestRequest(s.(*apiKitServer), "/status").Code)
+
+	invalid := loadServerTestConfiguration(t, sourceUrl, "/router")
+	invalid.Endpoints[0].EndpointName = "switch"
+
+	assert.NotNil(t, s.Reload(invalid))
+	assert.Equal(t, http.StatusOK, serveServerTestRequest(s.(*apiKitServer), "/status").Code)
+}
+
+func TestServerShouldServeConcurrentRequestsDuringReload(t *testing.T) {
+	sourceUrl := createServerTestSource(t)
+
+	s, err := CreateApiKitServer(http.DefaultClient, loadServerTestConfiguration(t, sourceUrl, "/router"), nil, testLogger{})
+	assert.Nil(t, err)
+
+	configs := []*config.ApiKitServerConfiguration{
+		loadServerTestConfiguration(t, sourceUrl, "/router", "/status"),
+		loadServerTestConfiguration(t, sourceUrl, "/router"),
+	}
+
+	wg := sync.WaitGroup{}
+	codes := make(chan int, 200)
+	for index := 0; index < 200; index += 1 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			codes <- serveServerTestRequest(s.(*apiKitServer), "/router").Code
+		}()
+	}
+
+	for index := 0; index < 20; index += 1 {
+		assert.Nil(t, s.Reload(configs[index%len(configs)]))
+	}
+
+	wg.Wait()
+	close(codes)
+
+	for code := range codes {
+		assert.Equal(t, http.StatusOK, code)
+	}
+}
@@ -0,0 +1,152 @@
+package source
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+)
+
+// Error indicating that a extracted value was not accepted by one of the value constraints
+type ConstraintViolationError struct {
+	SourceName string
+	ValueName  string
+	Constraint string
+	Value      interface{}
+}
+
+func (e *ConstraintViolationError) Error() string {
+	return fmt.Sprintf("source: value %s of source %s violates the %s constraint with %v", e.ValueName, e.SourceName, e.Constraint, e.Value)
+}
+
+type Constraints interface {
+	Check(value interface{}) error
+	CheckMonotonic(value interface{}) error
+}
+
+type constraints struct {
+	sourceName string
+	valueName  string
+	pattern    *regexp.Regexp
+	previous   *float64
+	cfg        *config.SourceValueConstraintsConfiguration
+}
+
+func CreateConstraints(sourceName string, valueName string, c *config.SourceValueConstraintsConfiguration) (Constraints, error) {
+	if c == nil {
+		return nil, fmt.Errorf("source: provided constraints config reference is nil")
+	}
+
+	var pattern *regexp.Regexp = nil
+	if len(c.Pattern) != 0 {
+		if regex, err := regexp.Compile(c.Pattern); err != nil {
+			return nil, fmt.Errorf("source: failed to compile the constraint pattern: %w", err)
+		} else {
+			pattern = regex
+		}
+	}
+
+	return &constraints{
+		sourceName: sourceName,
+		valueName:  valueName,
+		pattern:    pattern,
+		previous:   nil,
+		cfg:        c,
+	}, nil
+}
+
+func (c *constraints) violation(constraint string, value interface{}) error {
+	return &ConstraintViolationError{
+		SourceName: c.sourceName,
+		ValueName:  c.valueName,
+		Constraint: constraint,
+		Value:      value,
+	}
+}
+
+func (c *constraints) Check(value interface{}) error {
+	if c.cfg.Min != nil || c.cfg.Max != nil {
+		number, ok := toFloat64(value)
+		if !ok {
+			return c.violation("numeric", value)
+		}
+
+		if c.cfg.Min != nil && number < *c.cfg.Min {
+			return c.violation("min", value)
+		}
+
+		if c.cfg.Max != nil && number > *c.cfg.Max {
+			return c.violation("max", value)
+		}
+	}
+
+	text := fmt.Sprint(value)
+
+	if len(c.cfg.AllowedValues) != 0 {
+		allowed := false
+		for _, allowedValue := range c.cfg.AllowedValues {
+			if allowedValue == text {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return c.violation("allowed-values", value)
+		}
+	}
+
+	if c.pattern != nil && !c.pattern.MatchString(text) {
+		return c.violation("pattern", value)
+	}
+
+	length := utf8.RuneCountInString(text)
+	if c.cfg.MinLength != nil && length < *c.cfg.MinLength {
+		return c.violation("min-length", value)
+	}
+
+	if c.cfg.MaxLength != nil && length > *c.cfg.MaxLength {
+		return c.violation("max-length", value)
+	}
+
+	return nil
+}
+
+// Check if the value did not decrease since the previous value. The caller is responsible for synchronization
+func (c *constraints) CheckMonotonic(value interface{}) error {
+	if !c.cfg.Monotonic {
+		return nil
+	}
+
+	number, ok := toFloat64(value)
+	if !ok {
+		return c.violation("numeric", value)
+	}
+
+	// NOTE: The decreased value becomes the new baseline, so a counter reset is reported once instead of until the
+	// counter exceeds the value observed before the reset
+	decreased := c.previous != nil && number < *c.previous
+	c.previous = &number
+
+	if decreased && !c.cfg.ResetOnDecrease {
+		return c.violation("monotonic", value)
+	}
+
+	return nil
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	default:
+		return 0, false
+	}
+}
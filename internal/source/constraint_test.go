@@ -0,0 +1,71 @@
+package source
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstraintsShouldReportViolations(t *testing.T) {
+	min, max := 0.0, 100.0
+	constraints, err := CreateConstraints("router", "signal", &config.SourceValueConstraintsConfiguration{
+		Min:       &min,
+		Max:       &max,
+		Monotonic: true,
+	})
+	assert.Nil(t, err)
+
+	cases := []struct {
+		value      interface{}
+		constraint string
+	}{
+		{value: 10, constraint: ""},
+		{value: int64(100), constraint: ""},
+		{value: -1, constraint: "min"},
+		{value: 100.5, constraint: "max"},
+		{value: "10", constraint: "numeric"},
+	}
+
+	for _, c := range cases {
+		err := constraints.Check(c.value)
+		if len(c.constraint) == 0 {
+			assert.Nil(t, err)
+			continue
+		}
+
+		var violation *ConstraintViolationError
+		assert.True(t, errors.As(err, &violation))
+		assert.Equal(t, c.constraint, violation.Constraint)
+	}
+
+	assert.Nil(t, constraints.CheckMonotonic(5))
+	assert.Nil(t, constraints.CheckMonotonic(5))
+	assert.NotNil(t, constraints.CheckMonotonic(4))
+	assert.Nil(t, constraints.CheckMonotonic(6))
+}
+
+func TestConstraintsShouldAcceptMonotonicValueAfterReset(t *testing.T) {
+	cases := []struct {
+		resetOnDecrease bool
+		violations      []bool
+	}{
+		{resetOnDecrease: false, violations: []bool{false, false, true, false, false}},
+		{resetOnDecrease: true, violations: []bool{false, false, false, false, false}},
+	}
+
+	for _, c := range cases {
+		constraints, err := CreateConstraints("router", "uptime", &config.SourceValueConstraintsConfiguration{
+			Monotonic:       true,
+			ResetOnDecrease: c.resetOnDecrease,
+		})
+		assert.Nil(t, err)
+
+		for index, value := range []int{50000, 90000, 10, 20, 30} {
+			err := constraints.CheckMonotonic(value)
+
+			assert.Equal(t, c.violations[index], err != nil)
+		}
+	}
+}
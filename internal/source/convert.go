@@ -0,0 +1,249 @@
+package source
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+)
+
+var (
+	defaultBoolTruthyValues = []string{"true", "1", "yes", "on", "enabled"}
+	defaultBoolFalsyValues  = []string{"false", "0", "no", "off", "disabled"}
+)
+
+func ConvertValue(value string, t config.VariableType, c *config.SourceValueConfiguration) (interface{}, error) {
+	switch t {
+	case config.String:
+		return value, nil
+	case config.Int:
+		if valueI64, err := parseNumberInt64(value, c); err != nil {
+			return nil, fmt.Errorf("source: failed to parse the value as int: %w", err)
+		} else if valueI64 > math.MaxInt || valueI64 < math.MinInt {
+			return nil, fmt.Errorf("source: the int value is overflowing")
+		} else {
+			return int(valueI64), nil
+		}
+	case config.Int64:
+		if valueI64, err := parseNumberInt64(value, c); err != nil {
+			return nil, fmt.Errorf("source: failed to parse the value as int64: %w", err)
+		} else {
+			return valueI64, nil
+		}
+	case config.Uint64:
+		if number, err := ParseNumber(value, c); err != nil {
+			return nil, fmt.Errorf("source: failed to parse the value as uint64: %w", err)
+		} else if valueU64, err := number.Uint64(); err != nil {
+			return nil, fmt.Errorf("source: failed to parse the value as uint64: %w", err)
+		} else {
+			return valueU64, nil
+		}
+	case config.Float:
+		if number, err := ParseNumber(value, c); err != nil {
+			return nil, fmt.Errorf("source: failed to parse the value as float64: %w", err)
+		} else if valueF, err := number.Float64(); err != nil {
+			return nil, fmt.Errorf("source: failed to parse the value as float64: %w", err)
+		} else {
+			return valueF, nil
+		}
+	case config.Bool:
+		return ParseBool(value, c.BoolTruthyValues, c.BoolFalsyValues)
+	case config.Timestamp:
+		return ParseTimestamp(value, c.TimestampLayout, c.TimestampTimeZone)
+	case config.Duration:
+		// NOTE: The duration is represented as a whole number of seconds, so the fraction of a second is truncated
+		if duration, err := ParseDuration(value); err != nil {
+			return nil, err
+		} else {
+			return int64(duration.Seconds()), nil
+		}
+	case config.Ip:
+		if addr, err := netip.ParseAddr(value); err != nil {
+			return nil, fmt.Errorf("source: failed to parse the value as ip: %w", err)
+		} else {
+			return addr.Unmap().String(), nil
+		}
+	case config.Cidr:
+		if prefix, err := netip.ParsePrefix(value); err != nil {
+			return nil, fmt.Errorf("source: failed to parse the value as cidr: %w", err)
+		} else {
+			return prefix.Masked().String(), nil
+		}
+	case config.Mac:
+		return ParseMac(value)
+	default:
+		return nil, fmt.Errorf("source: invalid source value type specified")
+	}
+}
+
+func parseNumberInt64(value string, c *config.SourceValueConfiguration) (int64, error) {
+	number, err := ParseNumber(value, c)
+	if err != nil {
+		return 0, err
+	}
+
+	return number.Int64()
+}
+
+func ParseBool(value string, truthyValues []string, falsyValues []string) (bool, error) {
+	if len(truthyValues) == 0 {
+		truthyValues = defaultBoolTruthyValues
+	}
+
+	if len(falsyValues) == 0 {
+		falsyValues = defaultBoolFalsyValues
+	}
+
+	value = strings.TrimSpace(value)
+	for _, truthyValue := range truthyValues {
+		if strings.EqualFold(value, truthyValue) {
+			return true, nil
+		}
+	}
+
+	for _, falsyValue := range falsyValues {
+		if strings.EqualFold(value, falsyValue) {
+			return false, nil
+		}
+	}
+
+	return false, fmt.Errorf("source: value is neither a truthy nor a falsy value")
+}
+
+func ParseTimestamp(value string, layout string, timeZone string) (string, error) {
+	if len(layout) == 0 {
+		layout = time.RFC3339
+	}
+
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return "", fmt.Errorf("source: failed to load the timestamp time zone: %w", err)
+	}
+
+	if timestamp, err := time.ParseInLocation(layout, value, location); err != nil {
+		return "", fmt.Errorf("source: failed to parse the value as timestamp: %w", err)
+	} else {
+		return timestamp.Format(time.RFC3339), nil
+	}
+}
+
+var (
+	durationClockRegex = regexp.MustCompile(`^(\d+):(\d{1,2})(?::(\d{1,2}))?$`)
+	durationUnitRegex  = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-zA-Z]+)$`)
+	durationTokenRegex = regexp.MustCompile(`\d+(?:\.\d+)?\s*[a-zA-Z]+|\d+:\d{1,2}(?::\d{1,2})?|\d+(?:\.\d+)?`)
+	durationSepRegex   = regexp.MustCompile(`^[\s,]*$`)
+)
+
+// Parse a human readable duration such as "3d 04:12:09", "1 day, 2 hours", "5h30m" or a plain number of seconds. The
+// clock tokens are always read as hours first, so a two part token such as "04:12" is 4 hours and 12 minutes and never
+// 4 minutes and 12 seconds. Durations shorter than an hour have to be written in the full "0:04:12" form
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if len(value) == 0 {
+		return 0, fmt.Errorf("source: invalid empty duration provided")
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		return duration, nil
+	}
+
+	tokensIndexes := durationTokenRegex.FindAllStringIndex(value, -1)
+	if len(tokensIndexes) == 0 {
+		return 0, fmt.Errorf("source: failed to parse the value as duration")
+	}
+
+	// NOTE: The tokens must cover the whole value, only whitespaces and commas are allowed between them
+	tokens := make([]string, 0, len(tokensIndexes))
+	offset := 0
+	for _, tokenIndexes := range tokensIndexes {
+		if !durationSepRegex.MatchString(value[offset:tokenIndexes[0]]) {
+			return 0, fmt.Errorf("source: failed to parse the value as duration due to unexpected characters")
+		}
+
+		tokens = append(tokens, value[tokenIndexes[0]:tokenIndexes[1]])
+		offset = tokenIndexes[1]
+	}
+
+	if !durationSepRegex.MatchString(value[offset:]) {
+		return 0, fmt.Errorf("source: failed to parse the value as duration due to unexpected characters")
+	}
+
+	var duration time.Duration
+	for _, token := range tokens {
+		if matches := durationClockRegex.FindStringSubmatch(token); matches != nil {
+			hours, _ := strconv.Atoi(matches[1])
+			minutes, _ := strconv.Atoi(matches[2])
+			seconds := 0
+			if len(matches[3]) != 0 {
+				seconds, _ = strconv.Atoi(matches[3])
+			}
+
+			duration += time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+			continue
+		}
+
+		if matches := durationUnitRegex.FindStringSubmatch(token); matches != nil {
+			amount, _ := strconv.ParseFloat(matches[1], 64)
+
+			var unit time.Duration
+			switch strings.ToLower(matches[2]) {
+			case "w", "week", "weeks":
+				unit = 7 * 24 * time.Hour
+			case "d", "day", "days":
+				unit = 24 * time.Hour
+			case "h", "hr", "hrs", "hour", "hours":
+				unit = time.Hour
+			case "m", "min", "mins", "minute", "minutes":
+				unit = time.Minute
+			case "s", "sec", "secs", "second", "seconds":
+				unit = time.Second
+			case "ms":
+				unit = time.Millisecond
+			default:
+				return 0, fmt.Errorf("source: unsupported duration unit %s", matches[2])
+			}
+
+			duration += time.Duration(amount * float64(unit))
+			continue
+		}
+
+		// NOTE: A bare number is only accepted as the whole value and is interpreted as seconds
+		if len(tokens) != 1 {
+			return 0, fmt.Errorf("source: ambiguous unitless duration component")
+		}
+
+		seconds, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return 0, fmt.Errorf("source: failed to parse the value as duration: %w", err)
+		}
+
+		duration += time.Duration(seconds * float64(time.Second))
+	}
+
+	return duration, nil
+}
+
+// Parse and normalize a MAC address to the lowercase colon separated notation
+func ParseMac(value string) (string, error) {
+	value = strings.TrimSpace(value)
+
+	// NOTE: Bare hexadecimal notation such as "AABBCCDDEEFF" is not supported by the standard library
+	if len(value) == 12 {
+		if decoded, err := hex.DecodeString(value); err == nil {
+			return net.HardwareAddr(decoded).String(), nil
+		}
+	}
+
+	if mac, err := net.ParseMAC(value); err != nil {
+		return "", fmt.Errorf("source: failed to parse the value as mac: %w", err)
+	} else {
+		return mac.String(), nil
+	}
+}
@@ -0,0 +1,140 @@
+package source
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertValueShouldReturnCorrectValue(t *testing.T) {
+	cfg := &config.SourceValueConfiguration{
+		BoolTruthyValues:  []string{"Verbunden"},
+		BoolFalsyValues:   []string{"Getrennt"},
+		TimestampLayout:   "2006-01-02 15:04:05",
+		TimestampTimeZone: "Europe/Warsaw",
+	}
+
+	cases := []struct {
+		value    string
+		t        config.VariableType
+		expected interface{}
+	}{
+		{value: "4294967296", t: config.Int, expected: 4294967296},
+		{value: "-9223372036854775808", t: config.Int64, expected: int64(-9223372036854775808)},
+		{value: "18446744073709551615", t: config.Uint64, expected: uint64(18446744073709551615)},
+		{value: "verbunden", t: config.Bool, expected: true},
+		{value: "Getrennt", t: config.Bool, expected: false},
+		{value: "2024-07-01 12:30:00", t: config.Timestamp, expected: "2024-07-01T12:30:00+02:00"},
+		{value: "3d 04:12:09", t: config.Duration, expected: int64(274329)},
+		{value: "::ffff:192.168.1.1", t: config.Ip, expected: "192.168.1.1"},
+		{value: "2001:0db8:0000:0000:0000:0000:0000:0001", t: config.Ip, expected: "2001:db8::1"},
+		{value: "10.0.0.1/24", t: config.Cidr, expected: "10.0.0.0/24"},
+		{value: "AA-BB-CC-DD-EE-FF", t: config.Mac, expected: "aa:bb:cc:dd:ee:ff"},
+		{value: "AABBCCDDEEFF", t: config.Mac, expected: "aa:bb:cc:dd:ee:ff"},
+	}
+
+	for _, c := range cases {
+		actual, err := ConvertValue(c.value, c.t, cfg)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestConvertValueShouldFailForInvalidValues(t *testing.T) {
+	cfg := &config.SourceValueConfiguration{}
+
+	cases := []struct {
+		value string
+		t     config.VariableType
+	}{
+		{value: "maybe", t: config.Bool},
+		{value: "-1", t: config.Uint64},
+		{value: "300.1.1.1", t: config.Ip},
+		{value: "10.0.0.1", t: config.Cidr},
+		{value: "AA:BB", t: config.Mac},
+		{value: "3 fortnights", t: config.Duration},
+		{value: "abc 5", t: config.Duration},
+		{value: "5 minutes ago", t: config.Duration},
+		{value: "up 3d 04:12:09", t: config.Duration},
+	}
+
+	for _, c := range cases {
+		_, err := ConvertValue(c.value, c.t, cfg)
+
+		assert.NotNil(t, err)
+	}
+}
+
+func TestParseDurationShouldReturnCorrectValue(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{value: "3d 04:12:09", expected: 76*time.Hour + 12*time.Minute + 9*time.Second},
+		{value: "1 day, 2 hours, 3 minutes", expected: 26*time.Hour + 3*time.Minute},
+		{value: "5h30m", expected: 5*time.Hour + 30*time.Minute},
+		{value: "12:05", expected: 12*time.Hour + 5*time.Minute},
+		{value: "04:12", expected: 4*time.Hour + 12*time.Minute},
+		{value: "0:04:12", expected: 4*time.Minute + 12*time.Second},
+		{value: "2d 00:45", expected: 48*time.Hour + 45*time.Minute},
+		{value: "3600", expected: time.Hour},
+		{value: " 2h, 15m ", expected: 2*time.Hour + 15*time.Minute},
+		{value: "1.5s", expected: 1500 * time.Millisecond},
+	}
+
+	for _, c := range cases {
+		actual, err := ParseDuration(c.value)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestConvertValueShouldParseFormattedNumbers(t *testing.T) {
+	cases := []struct {
+		value    string
+		t        config.VariableType
+		cfg      *config.SourceValueConfiguration
+		expected interface{}
+	}{
+		{value: "1 234,56", t: config.Float, cfg: &config.SourceValueConfiguration{NumberDecimalSeparator: ",", NumberGroupingSeparator: " "}, expected: 1234.56},
+		{value: "1\u00a0234,56", t: config.Float, cfg: &config.SourceValueConfiguration{NumberDecimalSeparator: ",", NumberGroupingSeparator: " "}, expected: 1234.56},
+		{value: "1.234.567", t: config.Int, cfg: &config.SourceValueConfiguration{NumberDecimalSeparator: ",", NumberGroupingSeparator: "."}, expected: 1234567},
+		{value: "12.5 dBm", t: config.Float, cfg: &config.SourceValueConfiguration{NumberStripUnit: true}, expected: 12.5},
+		{value: "-73dBm", t: config.Int, cfg: &config.SourceValueConfiguration{NumberStripUnit: true}, expected: -73},
+		{value: "0x1F", t: config.Int, cfg: &config.SourceValueConfiguration{NumberAllowPrefixes: true}, expected: 31},
+		{value: "0b101", t: config.Uint64, cfg: &config.SourceValueConfiguration{NumberAllowPrefixes: true}, expected: uint64(5)},
+		{value: "3.2 GB", t: config.Uint64, cfg: &config.SourceValueConfiguration{NumberUnitNormalization: config.BytesUnitNormalization}, expected: uint64(3200000000)},
+		{value: "2 kB", t: config.Int64, cfg: &config.SourceValueConfiguration{NumberUnitNormalization: config.BinaryBytesUnitNormalization}, expected: int64(2048)},
+		{value: "1.5e3", t: config.Float, cfg: &config.SourceValueConfiguration{}, expected: 1500.0},
+	}
+
+	for _, c := range cases {
+		actual, err := ConvertValue(c.value, c.t, c.cfg)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestConvertValueShouldFailForOverflowingFormattedNumbers(t *testing.T) {
+	cfg := &config.SourceValueConfiguration{NumberUnitNormalization: config.BytesUnitNormalization}
+	cases := []struct {
+		value string
+		t     config.VariableType
+	}{
+		{value: "9223372036.854775807 GB", t: config.Int64},
+		{value: "-9223372037 GB", t: config.Int64},
+		{value: "18446744073.709551615 GB", t: config.Uint64},
+		{value: "-1 GB", t: config.Uint64},
+	}
+
+	for _, c := range cases {
+		_, err := ConvertValue(c.value, c.t, cfg)
+
+		assert.NotNil(t, err)
+	}
+}
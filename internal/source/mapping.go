@@ -0,0 +1,94 @@
+package source
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+)
+
+type Mapping interface {
+	Map(value string) (interface{}, error)
+}
+
+type mappingEntry struct {
+	match string
+	regex *regexp.Regexp
+	value interface{}
+}
+
+type mapping struct {
+	entries []mappingEntry
+	cfg     *config.MappingConfiguration
+}
+
+func CreateMapping(c *config.MappingConfiguration) (Mapping, error) {
+	if c == nil {
+		return nil, fmt.Errorf("source: provided mapping config reference is nil")
+	}
+
+	entries := make([]mappingEntry, 0, len(c.Entries))
+	for _, entryConfig := range c.Entries {
+		entry := mappingEntry{
+			match: entryConfig.Match,
+			regex: nil,
+			value: entryConfig.Value,
+		}
+
+		if len(entryConfig.Regex) != 0 {
+			pattern := entryConfig.Regex
+			if c.CaseInsensitive {
+				pattern = "(?i)" + pattern
+			}
+
+			if regex, err := regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("source: failed to compile the mapping entry regex: %w", err)
+			} else {
+				entry.regex = regex
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return &mapping{
+		entries: entries,
+		cfg:     c,
+	}, nil
+}
+
+func CreateMappings(c []*config.MappingConfiguration) (map[string]Mapping, error) {
+	mappings := make(map[string]Mapping, len(c))
+	for _, mappingConfig := range c {
+		if mapping, err := CreateMapping(mappingConfig); err != nil {
+			return nil, fmt.Errorf("source: failed to create the %s mapping: %w", mappingConfig.Name, err)
+		} else {
+			mappings[mappingConfig.Name] = mapping
+		}
+	}
+
+	return mappings, nil
+}
+
+func (m *mapping) Map(value string) (interface{}, error) {
+	for _, entry := range m.entries {
+		if entry.regex != nil {
+			if entry.regex.MatchString(value) {
+				return entry.value, nil
+			}
+
+			continue
+		}
+
+		if entry.match == value || (m.cfg.CaseInsensitive && strings.EqualFold(entry.match, value)) {
+			return entry.value, nil
+		}
+	}
+
+	if m.cfg.FailOnUnmatched {
+		return nil, fmt.Errorf("source: value not matched by the %s mapping", m.cfg.Name)
+	}
+
+	return m.cfg.Default, nil
+}
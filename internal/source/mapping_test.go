@@ -0,0 +1,54 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMappingShouldReturnCorrectValue(t *testing.T) {
+	mapping, err := CreateMapping(&config.MappingConfiguration{
+		Name: "link",
+		Entries: []*config.MappingEntryConfiguration{
+			{Match: "connected", Value: "up"},
+			{Match: "Verbunden", Value: "up"},
+			{Match: "1", Value: true},
+			{Regex: `led_green\.gif$`, Value: "up"},
+		},
+		Default:         nil,
+		CaseInsensitive: true,
+	})
+	assert.Nil(t, err)
+
+	cases := []struct {
+		value    string
+		expected interface{}
+	}{
+		{value: "Connected", expected: "up"},
+		{value: "verbunden", expected: "up"},
+		{value: "1", expected: true},
+		{value: "/img/LED_GREEN.gif", expected: "up"},
+		{value: "Disconnected", expected: nil},
+	}
+
+	for _, c := range cases {
+		actual, err := mapping.Map(c.value)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestMappingShouldFailOnUnmatchedValue(t *testing.T) {
+	mapping, err := CreateMapping(&config.MappingConfiguration{
+		Name:            "link",
+		Entries:         []*config.MappingEntryConfiguration{{Match: "connected", Value: "up"}},
+		FailOnUnmatched: true,
+	})
+	assert.Nil(t, err)
+
+	_, err = mapping.Map("disconnected")
+
+	assert.NotNil(t, err)
+}
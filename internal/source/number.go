@@ -0,0 +1,186 @@
+package source
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+)
+
+type number struct {
+	value      string
+	base       int
+	multiplier float64
+}
+
+var (
+	decimalByteUnits = map[string]float64{
+		"b": 1, "kb": 1e3, "mb": 1e6, "gb": 1e9, "tb": 1e12, "pb": 1e15,
+		"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50,
+	}
+
+	binaryByteUnits = map[string]float64{
+		"b": 1, "kb": 1 << 10, "mb": 1 << 20, "gb": 1 << 30, "tb": 1 << 40, "pb": 1 << 50,
+		"kib": 1 << 10, "mib": 1 << 20, "gib": 1 << 30, "tib": 1 << 40, "pib": 1 << 50,
+	}
+)
+
+// Normalize a number representation according to the value number format options into a form accepted by strconv
+func ParseNumber(value string, c *config.SourceValueConfiguration) (number, error) {
+	decimalSeparator := c.NumberDecimalSeparator
+	if len(decimalSeparator) == 0 {
+		decimalSeparator = "."
+	}
+
+	value = strings.Map(func(r rune) rune {
+		if r == '\u00a0' || r == '\u202f' {
+			return ' '
+		}
+
+		return r
+	}, value)
+	value = strings.TrimSpace(value)
+
+	sign := ""
+	if strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		sign, value = value[:1], strings.TrimSpace(value[1:])
+	}
+
+	base := 10
+	if c.NumberAllowPrefixes && len(value) > 2 && value[0] == '0' {
+		switch value[1] {
+		case 'x', 'X':
+			base = 16
+		case 'b', 'B':
+			base = 2
+		case 'o', 'O':
+			base = 8
+		}
+
+		if base != 10 {
+			value = value[2:]
+		}
+	}
+
+	// NOTE: Split the value into the numeric part and the unit suffix only if units are expected. The style property
+	// values are css lengths and percentages (e.g. 72%, 10px), so their units are always stripped
+	unit := ""
+	if c.NumberStripUnit || len(c.ExtractionStyleProperty) != 0 || c.NumberUnitNormalization != config.NoUnitNormalization {
+		numberEnd := strings.IndexFunc(value, func(r rune) bool {
+			if base == 16 && unicode.Is(unicode.ASCII_Hex_Digit, r) {
+				return false
+			}
+
+			if unicode.IsDigit(r) || strings.ContainsRune(decimalSeparator, r) || strings.ContainsRune(c.NumberGroupingSeparator, r) {
+				return false
+			}
+
+			return true
+		})
+
+		if numberEnd != -1 {
+			value, unit = value[:numberEnd], strings.TrimSpace(value[numberEnd:])
+		}
+	}
+
+	value = strings.TrimSpace(value)
+	if len(c.NumberGroupingSeparator) != 0 {
+		value = strings.ReplaceAll(value, c.NumberGroupingSeparator, "")
+	}
+
+	value = strings.ReplaceAll(value, decimalSeparator, ".")
+
+	multiplier := 1.0
+	if len(unit) != 0 {
+		switch c.NumberUnitNormalization {
+		case config.BytesUnitNormalization, config.BinaryBytesUnitNormalization:
+			units := decimalByteUnits
+			if c.NumberUnitNormalization == config.BinaryBytesUnitNormalization {
+				units = binaryByteUnits
+			}
+
+			unitMultiplier, ok := units[strings.ToLower(unit)]
+			if !ok {
+				return number{}, fmt.Errorf("source: unsupported number unit %s", unit)
+			}
+
+			multiplier = unitMultiplier
+		}
+	}
+
+	if len(value) == 0 {
+		return number{}, fmt.Errorf("source: number does not contain any digits")
+	}
+
+	return number{
+		value:      sign + value,
+		base:       base,
+		multiplier: multiplier,
+	}, nil
+}
+
+func (n number) Int64() (int64, error) {
+	if n.multiplier == 1 {
+		if value, err := strconv.ParseInt(n.value, n.base, 64); err != nil {
+			return 0, fmt.Errorf("source: failed to parse the number as integer: %w", err)
+		} else {
+			return value, nil
+		}
+	}
+
+	value, err := n.Float64()
+	if err != nil {
+		return 0, err
+	}
+
+	// NOTE: The float64 representation of math.MaxInt64 is 2^63, which is already out of range
+	value = math.Round(value)
+	if math.IsNaN(value) || value >= math.MaxInt64 || value < math.MinInt64 {
+		return 0, fmt.Errorf("source: the number is out of integer range")
+	}
+
+	return int64(value), nil
+}
+
+func (n number) Uint64() (uint64, error) {
+	if n.multiplier == 1 {
+		if value, err := strconv.ParseUint(n.value, n.base, 64); err != nil {
+			return 0, fmt.Errorf("source: failed to parse the number as unsigned integer: %w", err)
+		} else {
+			return value, nil
+		}
+	}
+
+	value, err := n.Float64()
+	if err != nil {
+		return 0, err
+	}
+
+	// NOTE: The float64 representation of math.MaxUint64 is 2^64, which is already out of range
+	value = math.Round(value)
+	if math.IsNaN(value) || value >= math.MaxUint64 || value < 0 {
+		return 0, fmt.Errorf("source: the number is out of unsigned integer range")
+	}
+
+	return uint64(value), nil
+}
+
+func (n number) Float64() (float64, error) {
+	if n.base != 10 {
+		value, err := strconv.ParseInt(n.value, n.base, 64)
+		if err != nil {
+			return 0, fmt.Errorf("source: failed to parse the number as integer: %w", err)
+		}
+
+		return float64(value) * n.multiplier, nil
+	}
+
+	if value, err := strconv.ParseFloat(n.value, 64); err != nil {
+		return 0, fmt.Errorf("source: failed to parse the number as float: %w", err)
+	} else {
+		return value * n.multiplier, nil
+	}
+}
@@ -2,6 +2,7 @@ package source
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -18,19 +19,34 @@ import (
 type Source interface {
 	GetValue(key string) (interface{}, error)
 	GetValues(keys []string) (map[string]interface{}, error)
+	GetValuesWithMetadata(keys []string) (map[string]interface{}, *SourceMetadata, error)
+}
+
+// Information about the html content the source values were extracted from. The source url is not part of the
+// metadata, as it may carry the source credentials
+type SourceMetadata struct {
+	FetchedAt time.Time
+	Cached    bool
 }
 
 type source struct {
 	httpClient       *http.Client
 	htmlContentCache utils.Cacheable[content.HtmlContent]
+	contentFetchedAt time.Time
+	contentCached    bool
 	valueKeys        map[string]bool
+	valueGroups      map[string]string
 	valueRegex       map[string]*regexp.Regexp
+	valueSelector    map[string]content.HtmlContentSelector
+	valueTransforms  map[string][]Transform
+	valueMappings    map[string]Mapping
+	valueConstraints map[string]Constraints
 	logger           log.Loggerp
 	cfg              *config.SourceConfiguration
 	mu               sync.Mutex
 }
 
-func CreateSource(h *http.Client, c *config.SourceConfiguration, l log.Logger) (Source, error) {
+func CreateSource(h *http.Client, c *config.SourceConfiguration, m map[string]Mapping, l log.Logger) (Source, error) {
 	if h == nil {
 		return nil, fmt.Errorf("source: provided http client reference is nil")
 	}
@@ -39,6 +55,10 @@ func CreateSource(h *http.Client, c *config.SourceConfiguration, l log.Logger) (
 		return nil, fmt.Errorf("source: provided config reference is nil")
 	}
 
+	if m == nil {
+		return nil, fmt.Errorf("source: provided mappings reference is nil")
+	}
+
 	if l == nil {
 		return nil, fmt.Errorf("source: provided logger reference is nil")
 	}
@@ -47,8 +67,14 @@ func CreateSource(h *http.Client, c *config.SourceConfiguration, l log.Logger) (
 	logger := log.CreatePrefixedLogger(prefix, l)
 
 	valueKeys := make(map[string]bool, len(c.Values))
+	valueGroups := make(map[string]string, 0)
 	for _, sourceValue := range c.Values {
 		valueKeys[sourceValue.Name] = true
+
+		for _, group := range sourceValue.ExtractionRegexGroups {
+			valueKeys[group.Name] = true
+			valueGroups[group.Name] = sourceValue.Name
+		}
 	}
 
 	valueRegex := make(map[string]*regexp.Regexp, len(c.Values))
@@ -64,17 +90,90 @@ func CreateSource(h *http.Client, c *config.SourceConfiguration, l log.Logger) (
 		}
 	}
 
+	for _, sourceValue := range c.Values {
+		if sourceValue.Table == nil {
+			continue
+		}
+
+		for _, rowFilter := range sourceValue.Table.RowFilters {
+			if regex, err := regexp.Compile(rowFilter.Regex); err != nil {
+				return nil, fmt.Errorf("source: failed to compile the source value table row filter regex: %w", err)
+			} else {
+				valueRegex[rowFilter.Regex] = regex
+			}
+		}
+	}
+
+	valueSelector := make(map[string]content.HtmlContentSelector, len(c.Values))
+	for _, sourceValue := range c.Values {
+		if selector, err := createSourceValueSelector(sourceValue); err != nil {
+			return nil, fmt.Errorf("source: failed to compile the source value selector: %w", err)
+		} else {
+			valueSelector[sourceValue.Name] = selector
+		}
+	}
+
+	valueTransforms := make(map[string][]Transform, len(c.Values))
+	for _, sourceValue := range c.Values {
+		if transforms, err := CreateTransforms(sourceValue.Transforms); err != nil {
+			return nil, fmt.Errorf("source: failed to create the source value transforms: %w", err)
+		} else {
+			valueTransforms[sourceValue.Name] = transforms
+		}
+	}
+
+	valueMappings := make(map[string]Mapping, len(c.Values))
+	for _, sourceValue := range c.Values {
+		if len(sourceValue.Mapping) == 0 {
+			continue
+		}
+
+		if mapping, ok := m[sourceValue.Mapping]; !ok {
+			return nil, fmt.Errorf("source: source value references non existing mapping")
+		} else {
+			valueMappings[sourceValue.Name] = mapping
+		}
+	}
+
+	valueConstraints := make(map[string]Constraints, len(c.Values))
+	for _, sourceValue := range c.Values {
+		if sourceValue.Constraints == nil {
+			continue
+		}
+
+		if constraints, err := CreateConstraints(c.Name, sourceValue.Name, sourceValue.Constraints); err != nil {
+			return nil, fmt.Errorf("source: failed to create the source value constraints: %w", err)
+		} else {
+			valueConstraints[sourceValue.Name] = constraints
+		}
+	}
+
 	return &source{
 		httpClient:       h,
 		htmlContentCache: utils.NewCacheable[content.HtmlContent](),
+		contentFetchedAt: time.Time{},
+		contentCached:    false,
 		valueKeys:        valueKeys,
+		valueGroups:      valueGroups,
 		valueRegex:       valueRegex,
+		valueSelector:    valueSelector,
+		valueTransforms:  valueTransforms,
+		valueMappings:    valueMappings,
+		valueConstraints: valueConstraints,
 		logger:           logger,
 		cfg:              c,
 		mu:               sync.Mutex{},
 	}, nil
 }
 
+func createSourceValueSelector(c *config.SourceValueConfiguration) (content.HtmlContentSelector, error) {
+	if len(c.CssSelector) != 0 {
+		return content.CreateCssSelector(c.CssSelector)
+	}
+
+	return content.CreateXpathSelector(c.Xpath)
+}
+
 func (s *source) GetValue(key string) (interface{}, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -97,6 +196,21 @@ func (s *source) GetValues(keys []string) (map[string]interface{}, error) {
 	}
 }
 
+func (s *source) GetValuesWithMetadata(keys []string) (map[string]interface{}, *SourceMetadata, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.GetValuesNoLock(keys...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("source: failed to access the source values: %w", err)
+	}
+
+	return values, &SourceMetadata{
+		FetchedAt: s.contentFetchedAt,
+		Cached:    s.contentCached,
+	}, nil
+}
+
 func (s *source) GetValuesNoLock(keys ...string) (map[string]interface{}, error) {
 	if !s.AreValueKeysValid(keys...) {
 		return nil, fmt.Errorf("source: invalid values keys provided")
@@ -108,11 +222,47 @@ func (s *source) GetValuesNoLock(keys ...string) (map[string]interface{}, error)
 	}
 
 	result := make(map[string]interface{}, len(keys))
+	groupsValues := make(map[string]map[string]interface{}, 0)
 	for _, key := range keys {
-		if value, err := s.GetSourceValue(key, htmlContent); err != nil {
-			return nil, fmt.Errorf("source: failed to access the source value: %w", err)
+		var (
+			value interface{}
+			err   error
+		)
+
+		if parentKey, ok := s.valueGroups[key]; ok {
+			value, err = s.GetRegexGroupValue(key, parentKey, htmlContent, groupsValues)
 		} else {
+			value, err = s.GetSourceValue(key, htmlContent)
+		}
+
+		if err == nil {
+			if constraints, ok := s.valueConstraints[key]; ok {
+				if err := constraints.CheckMonotonic(value); err != nil {
+					return nil, fmt.Errorf("source: the source value is not valid: %w", err)
+				}
+			}
+
 			result[key] = value
+			continue
+		}
+
+		// NOTE: Constraint violations are never replaced with defaults of optional values
+		var violation *ConstraintViolationError
+		if errors.As(err, &violation) {
+			return nil, fmt.Errorf("source: the source value is not valid: %w", err)
+		}
+
+		sourceValueConfig, ok := s.GetSourceValueConfiguration(key)
+		if !ok || sourceValueConfig.Required {
+			return nil, fmt.Errorf("source: failed to access the source value: %w", err)
+		}
+
+		s.logger.Debugf("Optional value %s is missing: %s", key, err)
+
+		// NOTE: Optional values without a default are omitted and resolved by the endpoint missing values strategy,
+		// while a null default is returned as a present null value
+		if sourceValueConfig.HasDefault {
+			result[key], _ = sourceValueConfig.TypedDefault()
 		}
 	}
 
@@ -135,7 +285,8 @@ func (s *source) AreValueKeysValid(keys ...string) bool {
 
 func (s *source) GetHtmlContent() (content.HtmlContent, error) {
 	if html, ok := s.htmlContentCache.Get(); ok && html != nil {
-		s.logger.Infof("Cached content used to resolve %s access", s.cfg.Url)
+		s.logger.Infof("Cached content used to resolve %s access", utils.StripUrlUserInfo(s.cfg.Url))
+		s.contentCached = true
 		return html, nil
 	}
 
@@ -155,98 +306,377 @@ func (s *source) GetHtmlContent() (content.HtmlContent, error) {
 		s.htmlContentCache.SetWithTTL(htmlContent, ttl)
 	}
 
-	s.logger.Infof("Request to resource made to resolve %s access", s.cfg.Url)
+	// NOTE: The fetch time is tracked alongside the cache in order to report the content age
+	s.contentFetchedAt = time.Now().UTC()
+	s.contentCached = false
+
+	s.logger.Infof("Request to resource made to resolve %s access", utils.StripUrlUserInfo(s.cfg.Url))
 	return htmlContent, nil
 }
 
-func (s *source) GetSourceValue(key string, html content.HtmlContent) (interface{}, error) {
-	var sourceValueConfig *config.SourceValueConfiguration = nil
+func (s *source) GetSourceValueConfiguration(key string) (*config.SourceValueConfiguration, bool) {
 	for _, config := range s.cfg.Values {
 		if config.Name == key {
-			sourceValueConfig = config
+			return config, true
+		}
+
+		if groupConfig, ok := config.RegexGroupValue(key); ok {
+			return groupConfig, true
 		}
 	}
 
-	if sourceValueConfig == nil {
+	return nil, false
+}
+
+func (s *source) GetSourceValue(key string, html content.HtmlContent) (interface{}, error) {
+	sourceValueConfig, ok := s.GetSourceValueConfiguration(key)
+	if !ok {
 		return nil, fmt.Errorf("source: failed to access the target source value configuration")
 	}
 
+	selector, ok := s.valueSelector[key]
+	if !ok {
+		return nil, fmt.Errorf("source: failed to access the target source value selector")
+	}
+
+	var sourceValuePreprocess content.HtmlContentValuePreprocess = func(in string) (string, error) {
+		if sourceValueConfig.ExtractionTrim {
+			in = strings.TrimSpace(in)
+		}
+
+		// NOTE: Regex groups are matched after the value is transformed in order to split it into the group values
+		if len(sourceValueConfig.ExtractionRegexGroups) != 0 {
+			return ApplyTransforms(in, s.valueTransforms[key])
+		}
+
+		if regex, ok := s.valueRegex[sourceValueConfig.ExtractionRegex]; ok {
+			matches := regex.FindStringSubmatch(in)
+			s.logger.Debugf("Regex \"%s\" matching result for value %s: %+v", sourceValueConfig.ExtractionRegex, key, matches)
+
+			if sourceValueConfig.ExtractionRegexIndex >= len(matches) {
+				return "", fmt.Errorf("source: source value extraction regex index out of matches range")
+			}
+
+			in = matches[sourceValueConfig.ExtractionRegexIndex]
+		}
+
+		return ApplyTransforms(in, s.valueTransforms[key])
+	}
+
 	var sourceValueElement content.HtmlContentElement
 	switch sourceValueConfig.ExtractionStrategy {
 	case config.First:
 		{
-			if element, found, err := html.GetFirstElement(sourceValueConfig.Xpath); err != nil {
-				return nil, fmt.Errorf("source: failed to extract first element via xpath: %w", err)
+			if element, found, err := html.GetFirstElement(selector); err != nil {
+				return nil, fmt.Errorf("source: failed to extract first element via selector: %w", err)
 			} else if !found {
-				return nil, fmt.Errorf("source: target first element to extract not found: %w", err)
+				return nil, fmt.Errorf("source: target first element to extract not found")
 			} else {
 				sourceValueElement = element
 			}
 		}
 	case config.Single:
 		{
-			if element, found, err := html.GetSingleElement(sourceValueConfig.Xpath); err != nil {
-				return nil, fmt.Errorf("source: failed to extract single element via xpath: %w", err)
+			if element, found, err := html.GetSingleElement(selector); err != nil {
+				return nil, fmt.Errorf("source: failed to extract single element via selector: %w", err)
 			} else if !found {
-				return nil, fmt.Errorf("source: target single element to extract not found: %w", err)
+				return nil, fmt.Errorf("source: target single element to extract not found")
 			} else {
 				sourceValueElement = element
 			}
 		}
+	case config.All:
+		{
+			elements, err := html.GetAllElements(selector)
+			if err != nil {
+				return nil, fmt.Errorf("source: failed to extract all elements via selector: %w", err)
+			}
+
+			if values, err := s.GetElementsValues(elements, sourceValueConfig, sourceValuePreprocess); err != nil {
+				return nil, fmt.Errorf("source: failed to get the elements values: %w", err)
+			} else {
+				return values, nil
+			}
+		}
 	default:
 		return nil, fmt.Errorf("source: invliad extraction strategy specified")
 	}
 
-	var sourceValuePreprocess content.HtmlContentValuePreprocess = func(in string) (string, error) {
-		if sourceValueConfig.ExtractionTrim {
-			in = strings.TrimSpace(in)
+	if sourceValueConfig.Kind == config.Table {
+		if value, err := s.GetTableValue(sourceValueElement, sourceValueConfig); err != nil {
+			return nil, fmt.Errorf("source: failed to get the table value: %w", err)
+		} else {
+			return value, nil
 		}
+	}
 
-		if regex, ok := s.valueRegex[sourceValueConfig.ExtractionRegex]; ok {
-			matches := regex.FindStringSubmatch(in)
-			s.logger.Debugf("Regex \"%s\" matching result for value %s: %+v", sourceValueConfig.ExtractionRegex, key, matches)
+	if value, err := s.GetElementValue(sourceValueElement, sourceValueConfig, sourceValuePreprocess); err != nil {
+		return nil, fmt.Errorf("source: failed to get the element value: %w", err)
+	} else {
+		return value, nil
+	}
+}
 
-			if sourceValueConfig.ExtractionRegexIndex >= len(matches) {
-				return "", fmt.Errorf("source: source value extraction regex index out of matches range")
-			}
+// Get the value of a named regex group. The parent value is extracted once and shared by all of its groups via the groups values map
+func (s *source) GetRegexGroupValue(key, parentKey string, html content.HtmlContent, groupsValues map[string]map[string]interface{}) (interface{}, error) {
+	groupValues, ok := groupsValues[parentKey]
+	if !ok {
+		value, err := s.GetSourceValue(parentKey, html)
+		if err != nil {
+			return nil, fmt.Errorf("source: failed to access the regex groups parent value: %w", err)
+		}
 
-			return matches[sourceValueConfig.ExtractionRegexIndex], nil
+		if groupValues, ok = value.(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("source: regex groups parent value is not a map of the group values")
 		}
 
-		return in, nil
+		groupsValues[parentKey] = groupValues
 	}
 
-	var sourceValue interface{}
-	switch sourceValueConfig.Type {
-	case config.Float:
-		{
-			if value, err := sourceValueElement.GetInnerTextFloat(sourceValuePreprocess); err != nil {
-				return nil, fmt.Errorf("source: failed to get inner text float value: %w", err)
-			} else {
-				sourceValue = value
+	if value, ok := groupValues[key]; !ok {
+		return nil, fmt.Errorf("source: target regex group value not found")
+	} else {
+		return value, nil
+	}
+}
+
+func (s *source) GetRegexGroupsValues(value string, c *config.SourceValueConfiguration) (interface{}, error) {
+	regex, ok := s.valueRegex[c.ExtractionRegex]
+	if !ok {
+		return nil, fmt.Errorf("source: failed to access the regex groups regex")
+	}
+
+	matches := regex.FindStringSubmatch(value)
+	s.logger.Debugf("Regex \"%s\" matching result for value %s: %+v", c.ExtractionRegex, c.Name, matches)
+
+	if matches == nil {
+		return nil, fmt.Errorf("source: source value extraction regex not matched")
+	}
+
+	values := make(map[string]interface{}, len(c.ExtractionRegexGroups))
+	for _, group := range c.ExtractionRegexGroups {
+		groupConfig, _ := c.RegexGroupValue(group.Name)
+
+		if groupValue, err := ConvertValue(matches[regex.SubexpIndex(group.Name)], group.Type, groupConfig); err != nil {
+			return nil, fmt.Errorf("source: failed to convert the %s regex group value: %w", group.Name, err)
+		} else {
+			values[group.Name] = groupValue
+		}
+	}
+
+	return values, nil
+}
+
+func (s *source) GetTableValue(element content.HtmlContentElement, c *config.SourceValueConfiguration) (interface{}, error) {
+	rows, err := element.GetTableRows()
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to access the table rows: %w", err)
+	}
+
+	if c.Table.SkipHeaderRows+c.Table.SkipFooterRows > len(rows) {
+		return nil, fmt.Errorf("source: table has less rows than the skipped header and footer rows")
+	}
+
+	rows = rows[c.Table.SkipHeaderRows : len(rows)-c.Table.SkipFooterRows]
+
+	columns := c.Table.Columns
+	if c.Table.HeaderRow {
+		if len(rows) == 0 {
+			return nil, fmt.Errorf("source: table header row not found")
+		}
+
+		if len(columns) == 0 {
+			columns = make([]*config.SourceValueTableColumnConfiguration, 0, len(rows[0]))
+			names := utils.NewEmptySet[string]()
+			for index, cell := range rows[0] {
+				name, err := cell.GetContentString(content.Text, nil)
+				if err != nil {
+					return nil, fmt.Errorf("source: failed to access the table header cell: %w", err)
+				}
+
+				// NOTE: The header names are used as the row object keys, so they must be unique and not empty
+				name = strings.TrimSpace(name)
+				if len(name) == 0 {
+					return nil, fmt.Errorf("source: table header cell %d has an empty name", index)
+				}
+
+				if !names.Add(name) {
+					return nil, fmt.Errorf("source: table header cell %d has the duplicate %s name", index, name)
+				}
+
+				columns = append(columns, &config.SourceValueTableColumnConfiguration{
+					Name:  name,
+					Index: index,
+					Type:  config.String,
+				})
 			}
 		}
-	case config.Int:
-		{
-			if value, err := sourceValueElement.GetInnerTextInt(sourceValuePreprocess); err != nil {
-				return nil, fmt.Errorf("source: failed to get inner text int value: %w", err)
+
+		rows = rows[1:]
+	}
+
+	result := make([]interface{}, 0, len(rows))
+	for _, row := range rows {
+		included, err := s.IsTableRowIncluded(row, columns, c.Table.RowFilters)
+		if err != nil {
+			return nil, fmt.Errorf("source: failed to apply the table row filters: %w", err)
+		}
+
+		if !included {
+			continue
+		}
+
+		object := make(map[string]interface{}, len(columns))
+		for _, column := range columns {
+			if column.Index >= len(row) {
+				return nil, fmt.Errorf("source: table column %s index out of row cells range", column.Name)
+			}
+
+			value, err := GetElementContentString(row[column.Index], c.ExtractionMode, nil)
+			if err != nil {
+				return nil, fmt.Errorf("source: failed to get the table column %s value: %w", column.Name, err)
+			}
+
+			if convertedValue, err := ConvertValue(value, column.Type, c); err != nil {
+				return nil, fmt.Errorf("source: failed to convert the table column %s value: %w", column.Name, err)
 			} else {
-				sourceValue = value
+				object[column.Name] = convertedValue
 			}
 		}
-	case config.String:
-		{
-			if value, err := sourceValueElement.GetInnerTextString(sourceValuePreprocess); err != nil {
-				return nil, fmt.Errorf("source: failed to get inner text string value: %w", err)
-			} else {
-				sourceValue = value
+
+		result = append(result, object)
+	}
+
+	return result, nil
+}
+
+func (s *source) IsTableRowIncluded(row []content.HtmlContentElement, columns []*config.SourceValueTableColumnConfiguration, filters []*config.SourceValueTableRowFilterConfiguration) (bool, error) {
+	for _, filter := range filters {
+		var column *config.SourceValueTableColumnConfiguration = nil
+		for _, c := range columns {
+			if c.Name == filter.Column {
+				column = c
 			}
 		}
+
+		if column == nil {
+			return false, fmt.Errorf("source: table row filter references non existing column")
+		}
+
+		regex, ok := s.valueRegex[filter.Regex]
+		if !ok {
+			return false, fmt.Errorf("source: failed to access the table row filter regex")
+		}
+
+		// NOTE: A row without the filtered cell is not silently dropped, same as in the column values extraction
+		if column.Index >= len(row) {
+			return false, fmt.Errorf("source: table row filter column %s index out of row cells range", column.Name)
+		}
+
+		value, err := row[column.Index].GetContentString(content.Text, nil)
+		if err != nil {
+			return false, fmt.Errorf("source: failed to access the table row filter cell: %w", err)
+		}
+
+		if regex.MatchString(value) == filter.Exclude {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (s *source) GetElementsValues(elements []content.HtmlContentElement, c *config.SourceValueConfiguration, preprocess content.HtmlContentValuePreprocess) (interface{}, error) {
+	if len(elements) < c.ExtractionMinCount {
+		return nil, fmt.Errorf("source: found %d elements which is less than the minimum of %d", len(elements), c.ExtractionMinCount)
+	}
+
+	if c.ExtractionMaxCount != 0 && len(elements) > c.ExtractionMaxCount {
+		return nil, fmt.Errorf("source: found %d elements which is more than the maximum of %d", len(elements), c.ExtractionMaxCount)
+	}
+
+	values := make([]interface{}, 0, len(elements))
+	for _, element := range elements {
+		if value, err := s.GetElementValue(element, c, preprocess); err != nil {
+			return nil, fmt.Errorf("source: failed to get the element value: %w", err)
+		} else {
+			values = append(values, value)
+		}
+	}
+
+	if !c.ExtractionJoin {
+		return values, nil
+	}
+
+	joinValues := make([]string, 0, len(values))
+	for _, value := range values {
+		joinValues = append(joinValues, fmt.Sprint(value))
+	}
+
+	return strings.Join(joinValues, c.ExtractionJoinSeparator), nil
+}
+
+func (s *source) GetElementValue(element content.HtmlContentElement, c *config.SourceValueConfiguration, preprocess content.HtmlContentValuePreprocess) (interface{}, error) {
+	var (
+		value string
+		err   error
+	)
+
+	switch {
+	case len(c.ExtractionAttribute) != 0:
+		value, err = element.GetAttributeValueString(c.ExtractionAttribute, preprocess)
+	case len(c.ExtractionStyleProperty) != 0:
+		value, err = element.GetStylePropertyString(c.ExtractionStyleProperty, preprocess)
 	default:
-		{
-			return nil, fmt.Errorf("source: invalid source value type specified")
+		value, err = GetElementContentString(element, c.ExtractionMode, preprocess)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("source: failed to access the element string value: %w", err)
+	}
+
+	if len(c.ExtractionRegexGroups) != 0 {
+		return s.GetRegexGroupsValues(value, c)
+	}
+
+	var convertedValue interface{}
+	if mapping, ok := s.valueMappings[c.Name]; ok {
+		if mappedValue, err := mapping.Map(value); err != nil {
+			return nil, fmt.Errorf("source: failed to map the element value: %w", err)
+		} else {
+			convertedValue = mappedValue
 		}
+	} else {
+		if typedValue, err := ConvertValue(value, c.Type, c); err != nil {
+			return nil, fmt.Errorf("source: failed to convert the element value: %w", err)
+		} else {
+			convertedValue = typedValue
+		}
+	}
+
+	if constraints, ok := s.valueConstraints[c.Name]; ok {
+		if err := constraints.Check(convertedValue); err != nil {
+			return nil, err
+		}
+	}
+
+	return convertedValue, nil
+}
+
+func GetElementContentString(element content.HtmlContentElement, m config.ExtractionMode, preprocess content.HtmlContentValuePreprocess) (string, error) {
+	var mode content.HtmlContentMode
+	switch m {
+	case config.Text:
+		mode = content.Text
+	case config.InnerHtml:
+		mode = content.InnerHtml
+	case config.OuterHtml:
+		mode = content.OuterHtml
+	case config.OwnText:
+		mode = content.OwnText
+	default:
+		return "", fmt.Errorf("source: invalid source value extraction mode specified")
 	}
 
-	return sourceValue, nil
+	return element.GetContentString(mode, preprocess)
 }
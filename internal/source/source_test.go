@@ -0,0 +1,198 @@
+package source
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogger struct{}
+
+func (testLogger) Debugf(prefix, format string, args ...interface{}) {}
+func (testLogger) Infof(prefix, format string, args ...interface{})  {}
+func (testLogger) Warnf(prefix, format string, args ...interface{})  {}
+func (testLogger) Errorf(prefix, format string, args ...interface{}) {}
+
+func createTestSource(t *testing.T, content string, values ...*config.SourceValueConfiguration) Source {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(content))
+	}))
+
+	t.Cleanup(server.Close)
+
+	source, err := CreateSource(server.Client(), &config.SourceConfiguration{
+		Name:           "router",
+		Url:            server.URL,
+		TimeoutSeconds: 5,
+		Values:         values,
+	}, map[string]Mapping{}, testLogger{})
+	assert.Nil(t, err)
+
+	return source
+}
+
+const testTableContent = `<table id="clients">
+	<tr><th>Name</th><th>Address</th></tr>
+	<tr><td>laptop</td><td>10.0.0.2</td></tr>
+	<tr><td>phone</td><td>10.0.0.3</td></tr>
+</table>`
+
+func TestGetValueShouldReturnTableRows(t *testing.T) {
+	source := createTestSource(t, testTableContent, &config.SourceValueConfiguration{
+		Name:  "clients",
+		Xpath: `//table[@id="clients"]`,
+		Kind:  config.Table,
+		Table: &config.SourceValueTableConfiguration{
+			HeaderRow:  true,
+			RowFilters: []*config.SourceValueTableRowFilterConfiguration{{Column: "Name", Regex: "^phone$", Exclude: true}},
+		},
+	})
+
+	actual, err := source.GetValue("clients")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"Name": "laptop", "Address": "10.0.0.2"}}, actual)
+}
+
+func TestGetValueShouldFailForInvalidTableHeaderNames(t *testing.T) {
+	cases := []string{
+		`<table><tr><th>Name</th><th>Name</th></tr><tr><td>laptop</td><td>10.0.0.2</td></tr></table>`,
+		`<table><tr><th>Name</th><th> </th></tr><tr><td>laptop</td><td>10.0.0.2</td></tr></table>`,
+	}
+
+	for _, c := range cases {
+		source := createTestSource(t, c, &config.SourceValueConfiguration{
+			Name:     "clients",
+			Xpath:    "//table",
+			Kind:     config.Table,
+			Required: true,
+			Table:    &config.SourceValueTableConfiguration{HeaderRow: true},
+		})
+
+		_, err := source.GetValue("clients")
+
+		assert.NotNil(t, err)
+	}
+}
+
+func TestGetValueShouldFailForShortTableRows(t *testing.T) {
+	content := `<table><tr><td>laptop</td><td>10.0.0.2</td></tr><tr><td>phone</td></tr></table>`
+	columns := []*config.SourceValueTableColumnConfiguration{
+		{Name: "name", Index: 0, Type: config.String},
+		{Name: "address", Index: 1, Type: config.String},
+	}
+
+	cases := []struct {
+		rowFilters []*config.SourceValueTableRowFilterConfiguration
+	}{
+		{rowFilters: nil},
+		{rowFilters: []*config.SourceValueTableRowFilterConfiguration{{Column: "address", Regex: "^10\\."}}},
+	}
+
+	for _, c := range cases {
+		source := createTestSource(t, content, &config.SourceValueConfiguration{
+			Name:     "clients",
+			Xpath:    "//table",
+			Kind:     config.Table,
+			Required: true,
+			Table:    &config.SourceValueTableConfiguration{Columns: columns, RowFilters: c.rowFilters},
+		})
+
+		_, err := source.GetValue("clients")
+
+		assert.ErrorContains(t, err, "index out of row cells range")
+	}
+}
+
+const testListContent = `<ul><li>10.0.0.1</li><li>10.0.0.2</li><li>10.0.0.3</li></ul>`
+
+func TestGetValueShouldReturnAllElementsValues(t *testing.T) {
+	cases := []struct {
+		value    *config.SourceValueConfiguration
+		expected interface{}
+	}{
+		{
+			value:    &config.SourceValueConfiguration{Name: "dns", Xpath: "//li", ExtractionStrategy: config.All, Type: config.Ip},
+			expected: []interface{}{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		},
+		{
+			value:    &config.SourceValueConfiguration{Name: "dns", Xpath: "//li", ExtractionStrategy: config.All, ExtractionJoin: true, ExtractionJoinSeparator: ", ", Type: config.Ip},
+			expected: "10.0.0.1, 10.0.0.2, 10.0.0.3",
+		},
+		{
+			value:    &config.SourceValueConfiguration{Name: "dns", Xpath: "//li", ExtractionStrategy: config.All, ExtractionMinCount: 3, ExtractionMaxCount: 3, Type: config.String},
+			expected: []interface{}{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		},
+		{
+			value:    &config.SourceValueConfiguration{Name: "dns", Xpath: "//ol/li", ExtractionStrategy: config.All, Type: config.String},
+			expected: []interface{}{},
+		},
+	}
+
+	for _, c := range cases {
+		source := createTestSource(t, testListContent, c.value)
+
+		actual, err := source.GetValue("dns")
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
+
+func TestGetValueShouldFailForAllElementsCountViolations(t *testing.T) {
+	cases := []struct {
+		minCount int
+		maxCount int
+		expected string
+	}{
+		{minCount: 4, maxCount: 0, expected: "found 3 elements which is less than the minimum of 4"},
+		{minCount: 0, maxCount: 2, expected: "found 3 elements which is more than the maximum of 2"},
+	}
+
+	for _, c := range cases {
+		source := createTestSource(t, testListContent, &config.SourceValueConfiguration{
+			Name:               "dns",
+			Xpath:              "//li",
+			ExtractionStrategy: config.All,
+			ExtractionMinCount: c.minCount,
+			ExtractionMaxCount: c.maxCount,
+			Type:               config.String,
+			Required:           true,
+		})
+
+		_, err := source.GetValue("dns")
+
+		assert.ErrorContains(t, err, c.expected)
+	}
+}
+
+func TestGetValueShouldStripStylePropertyUnits(t *testing.T) {
+	cases := []struct {
+		style    string
+		t        config.VariableType
+		expected interface{}
+	}{
+		{style: "width: 72%", t: config.Float, expected: 72.0},
+		{style: "width: 72.5%", t: config.Float, expected: 72.5},
+		{style: "color: red; width: 10px !important", t: config.Int, expected: 10},
+		{style: "width: 72%", t: config.String, expected: "72%"},
+	}
+
+	for _, c := range cases {
+		source := createTestSource(t, `<div id="signal" style="`+c.style+`"></div>`, &config.SourceValueConfiguration{
+			Name:                    "signal",
+			Xpath:                   `//div[@id="signal"]`,
+			ExtractionStyleProperty: "width",
+			Type:                    c.t,
+			Required:                true,
+		})
+
+		actual, err := source.GetValue("signal")
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
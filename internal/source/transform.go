@@ -0,0 +1,132 @@
+package source
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"golang.org/x/text/unicode/norm"
+)
+
+type Transform func(in string) (string, error)
+
+func CreateTransform(c *config.SourceValueTransformConfiguration) (Transform, error) {
+	if c == nil {
+		return nil, fmt.Errorf("source: provided transform config reference is nil")
+	}
+
+	switch c.Type {
+	case config.TrimTransform:
+		return func(in string) (string, error) {
+			return strings.TrimSpace(in), nil
+		}, nil
+	case config.ReplaceTransform:
+		return func(in string) (string, error) {
+			return strings.ReplaceAll(in, c.Old, c.New), nil
+		}, nil
+	case config.RegexExtractTransform:
+		regex, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("source: failed to compile the regex extract transform regex: %w", err)
+		}
+
+		return func(in string) (string, error) {
+			matches := regex.FindStringSubmatch(in)
+			if c.RegexIndex >= len(matches) {
+				return "", fmt.Errorf("source: regex extract transform index out of matches range")
+			}
+
+			return matches[c.RegexIndex], nil
+		}, nil
+	case config.RegexReplaceTransform:
+		regex, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("source: failed to compile the regex replace transform regex: %w", err)
+		}
+
+		return func(in string) (string, error) {
+			return regex.ReplaceAllString(in, c.Replacement), nil
+		}, nil
+	case config.LowerTransform:
+		return func(in string) (string, error) {
+			return strings.ToLower(in), nil
+		}, nil
+	case config.UpperTransform:
+		return func(in string) (string, error) {
+			return strings.ToUpper(in), nil
+		}, nil
+	case config.SplitTransform:
+		return func(in string) (string, error) {
+			parts := strings.Split(in, c.Separator)
+
+			// NOTE: Negative indexes are counted from the end of the parts
+			index := c.Index
+			if index < 0 {
+				index += len(parts)
+			}
+
+			if index < 0 || index >= len(parts) {
+				return "", fmt.Errorf("source: split transform index out of parts range")
+			}
+
+			return parts[index], nil
+		}, nil
+	case config.UnicodeNormalizeTransform:
+		return func(in string) (string, error) {
+			in = strings.ReplaceAll(in, "&nbsp;", " ")
+			return norm.NFKC.String(in), nil
+		}, nil
+	case config.StripPrefixTransform:
+		return func(in string) (string, error) {
+			return strings.TrimPrefix(in, c.Value), nil
+		}, nil
+	case config.StripSuffixTransform:
+		return func(in string) (string, error) {
+			return strings.TrimSuffix(in, c.Value), nil
+		}, nil
+	case config.MultiplyTransform, config.OffsetTransform:
+		return func(in string) (string, error) {
+			value, err := strconv.ParseFloat(strings.TrimSpace(in), 64)
+			if err != nil {
+				return "", fmt.Errorf("source: failed to parse the arithmetic transform input as float: %w", err)
+			}
+
+			if c.Type == config.MultiplyTransform {
+				value *= c.Operand
+			} else {
+				value += c.Operand
+			}
+
+			return strconv.FormatFloat(value, 'f', -1, 64), nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("source: invalid transform type specified")
+	}
+}
+
+func CreateTransforms(c []*config.SourceValueTransformConfiguration) ([]Transform, error) {
+	transforms := make([]Transform, 0, len(c))
+	for _, transformConfig := range c {
+		if transform, err := CreateTransform(transformConfig); err != nil {
+			return nil, fmt.Errorf("source: failed to create the transform: %w", err)
+		} else {
+			transforms = append(transforms, transform)
+		}
+	}
+
+	return transforms, nil
+}
+
+func ApplyTransforms(in string, transforms []Transform) (string, error) {
+	for index, transform := range transforms {
+		if out, err := transform(in); err != nil {
+			return "", fmt.Errorf("source: transform %d failed: %w", index, err)
+		} else {
+			in = out
+		}
+	}
+
+	return in, nil
+}
@@ -0,0 +1,56 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/Krzysztofz01/apikit/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyTransformsShouldReturnCorrectValue(t *testing.T) {
+	cases := []struct {
+		in       string
+		c        []*config.SourceValueTransformConfiguration
+		expected string
+	}{
+		{
+			in: "  Up: 12,3 Mbps / Down: 45,6 Mbps ",
+			c: []*config.SourceValueTransformConfiguration{
+				{Type: config.TrimTransform},
+				{Type: config.SplitTransform, Separator: "/", Index: -1},
+				{Type: config.RegexExtractTransform, Regex: `([\d,]+)`, RegexIndex: 1},
+				{Type: config.ReplaceTransform, Old: ",", New: "."},
+				{Type: config.MultiplyTransform, Operand: 1000},
+			},
+			expected: "45600",
+		},
+		{
+			in: "Temp&nbsp;４２°C",
+			c: []*config.SourceValueTransformConfiguration{
+				{Type: config.UnicodeNormalizeTransform},
+				{Type: config.StripPrefixTransform, Value: "Temp "},
+				{Type: config.StripSuffixTransform, Value: "°C"},
+				{Type: config.OffsetTransform, Operand: -2},
+			},
+			expected: "40",
+		},
+		{
+			in: "Link: Connected",
+			c: []*config.SourceValueTransformConfiguration{
+				{Type: config.RegexReplaceTransform, Regex: `^Link:\s*`, Replacement: ""},
+				{Type: config.UpperTransform},
+			},
+			expected: "CONNECTED",
+		},
+	}
+
+	for _, c := range cases {
+		transforms, err := CreateTransforms(c.c)
+		assert.Nil(t, err)
+
+		actual, err := ApplyTransforms(c.in, transforms)
+
+		assert.Nil(t, err)
+		assert.Equal(t, c.expected, actual)
+	}
+}
@@ -0,0 +1,169 @@
+package utils
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Upper bound of the array indexes of a value path, as the arrays are allocated up to the greatest index
+const MaxPathIndex int = 1024
+
+// Single segment of a value path. The segment is either a object key or a array index
+type PathSegment struct {
+	Key     string
+	Index   int
+	IsIndex bool
+}
+
+// Parse a dotted value path with optional array indexes such as "wan.dns[0]" into segments
+func ParsePath(path string) ([]PathSegment, error) {
+	if len(path) == 0 {
+		return nil, fmt.Errorf("utils: invalid empty path provided")
+	}
+
+	segments := make([]PathSegment, 0)
+	for _, part := range strings.Split(path, ".") {
+		key, indexes, hasIndexes := strings.Cut(part, "[")
+		if len(key) == 0 {
+			return nil, fmt.Errorf("utils: path contains a empty key")
+		}
+
+		segments = append(segments, PathSegment{Key: key})
+
+		if !hasIndexes {
+			continue
+		}
+
+		for _, index := range strings.Split("["+indexes, "[")[1:] {
+			index, ok := strings.CutSuffix(index, "]")
+			if !ok {
+				return nil, fmt.Errorf("utils: path contains a unterminated index")
+			}
+
+			value, err := strconv.Atoi(index)
+			if err != nil || value < 0 {
+				return nil, fmt.Errorf("utils: path contains a invalid index")
+			}
+
+			if value > MaxPathIndex {
+				return nil, fmt.Errorf("utils: path contains a index greater than %d", MaxPathIndex)
+			}
+
+			segments = append(segments, PathSegment{Index: value, IsIndex: true})
+		}
+	}
+
+	return segments, nil
+}
+
+type pathObject map[string]interface{}
+
+type pathArray map[int]interface{}
+
+// Build a nested structure of objects and arrays from values represented by their paths. Missing array elements are nil
+func NestPaths(values map[string]interface{}) (map[string]interface{}, error) {
+	paths := make([]string, 0, len(values))
+	for path := range values {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	root := pathObject{}
+	for _, path := range paths {
+		segments, err := ParsePath(path)
+		if err != nil {
+			return nil, fmt.Errorf("utils: failed to parse the %s path: %w", path, err)
+		}
+
+		if err := setPathValue(root, segments, values[path]); err != nil {
+			return nil, fmt.Errorf("utils: failed to set the %s path value: %w", path, err)
+		}
+	}
+
+	return resolvePathContainers(root).(map[string]interface{}), nil
+}
+
+func setPathValue(node interface{}, segments []PathSegment, value interface{}) error {
+	segment, last := segments[0], len(segments) == 1
+
+	var (
+		child  interface{}
+		exists bool
+	)
+
+	switch container := node.(type) {
+	case pathObject:
+		if segment.IsIndex {
+			return fmt.Errorf("utils: path indexes a object")
+		}
+
+		child, exists = container[segment.Key]
+	case pathArray:
+		if !segment.IsIndex {
+			return fmt.Errorf("utils: path accesses a key of a array")
+		}
+
+		child, exists = container[segment.Index]
+	default:
+		return fmt.Errorf("utils: path conflicts with a value")
+	}
+
+	if last {
+		if exists {
+			return fmt.Errorf("utils: path conflicts with a existing value or container")
+		}
+
+		setPathChild(node, segment, value)
+		return nil
+	}
+
+	if !exists {
+		if segments[1].IsIndex {
+			child = pathArray{}
+		} else {
+			child = pathObject{}
+		}
+
+		setPathChild(node, segment, child)
+	}
+
+	return setPathValue(child, segments[1:], value)
+}
+
+func setPathChild(node interface{}, segment PathSegment, value interface{}) {
+	switch container := node.(type) {
+	case pathObject:
+		container[segment.Key] = value
+	case pathArray:
+		container[segment.Index] = value
+	}
+}
+
+func resolvePathContainers(node interface{}) interface{} {
+	switch container := node.(type) {
+	case pathObject:
+		object := make(map[string]interface{}, len(container))
+		for key, value := range container {
+			object[key] = resolvePathContainers(value)
+		}
+
+		return object
+	case pathArray:
+		length := 0
+		for index := range container {
+			length = max(length, index+1)
+		}
+
+		array := make([]interface{}, length)
+		for index, value := range container {
+			array[index] = resolvePathContainers(value)
+		}
+
+		return array
+	default:
+		return node
+	}
+}
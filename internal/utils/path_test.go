@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePathShouldReturnCorrectSegments(t *testing.T) {
+	segments, err := ParsePath("wan.dns[1][0].ip")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []PathSegment{
+		{Key: "wan"},
+		{Key: "dns"},
+		{Index: 1, IsIndex: true},
+		{Index: 0, IsIndex: true},
+		{Key: "ip"},
+	}, segments)
+}
+
+func TestParsePathShouldFailForInvalidPaths(t *testing.T) {
+	cases := []string{"", "wan..ip", "dns[", "dns[-1]", "dns[a]", ".ip", "dns[1025]", "dns[1000000000]"}
+
+	for _, c := range cases {
+		_, err := ParsePath(c)
+
+		assert.NotNil(t, err, c)
+	}
+}
+
+func TestNestPathsShouldBuildNestedValues(t *testing.T) {
+	actual, err := NestPaths(map[string]interface{}{
+		"uptime":     12,
+		"wan.ip":     "10.0.0.2",
+		"wan.dns[0]": "1.1.1.1",
+		"wan.dns[2]": "8.8.8.8",
+		"lan.mask":   map[string]interface{}{"bits": 24},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"uptime": 12,
+		"wan": map[string]interface{}{
+			"ip":  "10.0.0.2",
+			"dns": []interface{}{"1.1.1.1", nil, "8.8.8.8"},
+		},
+		"lan": map[string]interface{}{
+			"mask": map[string]interface{}{"bits": 24},
+		},
+	}, actual)
+}
+
+func TestNestPathsShouldFailForConflictingPaths(t *testing.T) {
+	cases := []map[string]interface{}{
+		{"wan": 1, "wan.ip": 2},
+		{"wan.dns[0]": 1, "wan.dns.primary": 2},
+		{"wan.ip": 1, "wan.ip[0]": 2},
+	}
+
+	for _, c := range cases {
+		_, err := NestPaths(c)
+
+		assert.NotNil(t, err)
+	}
+}
@@ -4,6 +4,7 @@ type Set[T comparable] interface {
 	Add(element T) bool
 	Remove(element T) bool
 	Contains(element T) bool
+	Elements() []T
 	Clear()
 }
 
@@ -29,6 +30,15 @@ func (s *set[T]) Contains(element T) bool {
 	return ok
 }
 
+func (s *set[T]) Elements() []T {
+	elements := make([]T, 0, len(s.data))
+	for element := range s.data {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
 func (s *set[T]) Remove(element T) bool {
 	if _, ok := s.data[element]; !ok {
 		return false
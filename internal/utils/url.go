@@ -0,0 +1,17 @@
+package utils
+
+import "net/url"
+
+const invalidUrlPlaceholder string = "[invalid url]"
+
+// Remove the user info (e.g. the basic auth credentials) from the url in order to make it safe to log. A url that can
+// not be parsed is replaced with a placeholder, as it is not possible to tell which part carries the credentials
+func StripUrlUserInfo(rawUrl string) string {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return invalidUrlPlaceholder
+	}
+
+	u.User = nil
+	return u.String()
+}